@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/a-h/ver/diff"
+	"github.com/a-h/ver/git"
+)
+
+// changelogSections are the sections of a Keep a Changelog release, in the order they're written.
+var changelogSections = []string{"Added", "Changed", "Deprecated", "Removed"}
+
+// changelog writes the changes to the API of a release in a history written with the -o and -s
+// flags as Markdown, in the format of https://keepachangelog.com
+func changelog(args []string) error {
+	flags := flag.NewFlagSet("changelog", flag.ContinueOnError)
+	in := flags.String("i", "", "The JSON history to read, written by ver with the -o and -s flags.")
+	out := flags.String("o", "", "The file to write the Markdown to. Defaults to stdout.")
+	from := flags.String("from", "", "The hash or version of the commit before the release. Defaults to the first commit.")
+	to := flags.String("to", "", "The hash or version of the last commit in the release. Defaults to the last commit.")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *in == "" {
+		return fmt.Errorf("please provide an input history with the -i parameter")
+	}
+
+	inFile, err := os.Open(*in)
+	if err != nil {
+		return fmt.Errorf("failed to open input history: %v", err)
+	}
+	defer inFile.Close()
+
+	signatures, err := readHistory(inFile)
+	if err != nil {
+		return err
+	}
+
+	r, err := newRelease(signatures, *from, *to)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return writeChangelog(os.Stdout, r)
+	}
+
+	outFile, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %v", err)
+	}
+	defer outFile.Close()
+
+	return writeChangelog(outFile, r)
+}
+
+// writeChangelog writes the changes of the release as Markdown, in sections of added, changed,
+// deprecated and removed items. Each change is followed by the commits which made it.
+func writeChangelog(w io.Writer, r release) error {
+	sections := map[string][]string{}
+
+	for _, c := range r.Changes {
+		section := changelogSection(c.Kind)
+		sections[section] = append(sections[section], "- "+describeReleaseChange(c)+describeCommits(c.Commits))
+	}
+
+	lines := []string{fmt.Sprintf("## [%v] - %s", r.Version, r.Commit.Date().Format("2006-01-02"))}
+
+	for _, section := range changelogSections {
+		if len(sections[section]) == 0 {
+			continue
+		}
+
+		lines = append(lines, "", "### "+section, "")
+		lines = append(lines, sections[section]...)
+	}
+
+	if len(r.Changes) == 0 {
+		lines = append(lines, "", "No changes to the exported API.")
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+
+	return err
+}
+
+// changelogSection returns the section of the changelog a kind of change is written in.
+func changelogSection(k diff.Kind) string {
+	switch k {
+	case diff.Added:
+		return "Added"
+	case diff.Deprecated:
+		return "Deprecated"
+	case diff.Removed:
+		return "Removed"
+	}
+
+	return "Changed"
+}
+
+// describeReleaseChange returns the item which was added or removed, or the name of the item
+// which was changed along with its package and the reason for the change.
+func describeReleaseChange(c releaseChange) string {
+	switch c.Kind {
+	case diff.Added:
+		return "`" + c.Current + "`"
+	case diff.Removed:
+		return "`" + c.Previous + "`"
+	}
+
+	description := "`" + c.Name + "` in `" + c.Package + "`"
+
+	if c.Reason != "" {
+		description += ": " + c.Reason
+	}
+
+	if c.Impact == diff.Breaking {
+		description = "**Breaking:** " + description
+	}
+
+	return description
+}
+
+// describeCommits returns the abbreviated hash, title and author of each commit, e.g.
+// " (8e162fc Update README.md, Adrian Hesketh)".
+func describeCommits(commits []git.Commit) string {
+	if len(commits) == 0 {
+		return ""
+	}
+
+	descriptions := make([]string, len(commits))
+
+	for i, c := range commits {
+		descriptions[i] = shortHash(c.Hash) + " " + c.Title + ", " + c.Name
+	}
+
+	return " (" + strings.Join(descriptions, "; ") + ")"
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+
+	return hash
+}
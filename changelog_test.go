@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/a-h/ver/diff"
+)
+
+func TestThatAChangelogCanBeWritten(t *testing.T) {
+	r, err := newRelease(testHistory(), "", "")
+
+	if err != nil {
+		t.Fatalf("failed to calculate the release: %v", err)
+	}
+
+	r.Commit.Timestamp = 1481976000 // 2016-12-17 12:00 UTC.
+
+	buf := new(bytes.Buffer)
+	if err = writeChangelog(buf, r); err != nil {
+		t.Fatalf("failed to write the changelog: %v", err)
+	}
+
+	expected := "## [1.1.3] - 2016-12-17\n" +
+		"\n" +
+		"### Added\n" +
+		"\n" +
+		"- `func a.New() error` (bbbbbbb Added New, Name B)\n" +
+		"\n" +
+		"### Removed\n" +
+		"\n" +
+		"- `func a.Old(s string)` (ddddddd Removed Old, Name D)\n"
+
+	if actual := buf.String(); actual != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, actual)
+	}
+}
+
+func TestThatChangesAreWrittenInTheirChangelogSection(t *testing.T) {
+	r := release{
+		Version: Version{2, 0, 0},
+		Changes: []releaseChange{
+			{Package: "a", Change: diff.Change{Kind: diff.UnderlyingTypeChanged, Name: "Mode", Impact: diff.Breaking, Reason: "changed from int to string"}},
+			{Package: "a", Change: diff.Change{Kind: diff.Deprecated, Name: "(*Client).Fetch", Impact: diff.Compatible, Reason: "deprecated: use Get."}},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := writeChangelog(buf, r); err != nil {
+		t.Fatalf("failed to write the changelog: %v", err)
+	}
+
+	expected := []string{
+		"### Changed\n\n- **Breaking:** `Mode` in `a`: changed from int to string\n",
+		"### Deprecated\n\n- `(*Client).Fetch` in `a`: deprecated: use Get.\n",
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("expected the changelog to contain:\n%s\nbut got:\n%s", e, buf.String())
+		}
+	}
+}
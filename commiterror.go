@@ -0,0 +1,56 @@
+package main
+
+import (
+	"github.com/a-h/ver/signature"
+)
+
+// ErrorCategory is the stage of the analysis of a commit which failed.
+type ErrorCategory string
+
+const (
+	// CheckoutFailed is used when the commit couldn't be checked out of the git repository.
+	CheckoutFailed ErrorCategory = "checkout"
+	// DependencyFetchFailed is used when the dependencies of the commit couldn't be downloaded.
+	DependencyFetchFailed ErrorCategory = "dependencies"
+	// TypeCheckFailed is used when the Go code couldn't be parsed or type checked.
+	TypeCheckFailed ErrorCategory = "typecheck"
+	// NoGoFiles is used when the commit doesn't contain any Go files.
+	NoGoFiles ErrorCategory = "nogofiles"
+	// AnalysisFailed is used when the signature couldn't be calculated for any other reason.
+	AnalysisFailed ErrorCategory = "analysis"
+)
+
+// CommitError describes why a commit couldn't be analysed.
+type CommitError struct {
+	Category ErrorCategory `json:"category"`
+	Message  string        `json:"message"`
+	// TypeErrors are the errors reported by the type checker, including their positions.
+	TypeErrors []signature.TypeError `json:"typeErrors,omitempty"`
+}
+
+func (e *CommitError) Error() string {
+	return string(e.Category) + ": " + e.Message
+}
+
+// newCommitError creates a CommitError in the given category.
+func newCommitError(category ErrorCategory, err error) *CommitError {
+	return &CommitError{
+		Category: category,
+		Message:  err.Error(),
+	}
+}
+
+// newSignatureError creates a CommitError from an error returned by the signature package.
+func newSignatureError(err error) *CommitError {
+	if err == signature.ErrNoGoFiles {
+		return newCommitError(NoGoFiles, err)
+	}
+
+	if le, ok := err.(signature.LoadError); ok {
+		ce := newCommitError(TypeCheckFailed, err)
+		ce.TypeErrors = le.TypeErrors
+		return ce
+	}
+
+	return newCommitError(AnalysisFailed, err)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatSignatureErrorsAreCategorised(t *testing.T) {
+	typeErrors := []signature.TypeError{
+		{Position: signature.Position{File: "a.go", Line: 3, Column: 9}, Message: "undeclared name: x"},
+	}
+
+	tests := []struct {
+		name               string
+		err                error
+		expectedCategory   ErrorCategory
+		expectedTypeErrors int
+	}{
+		{
+			name:             "No Go files",
+			err:              signature.ErrNoGoFiles,
+			expectedCategory: NoGoFiles,
+		},
+		{
+			name:               "Type check failure",
+			err:                signature.LoadError{Err: errors.New("couldn't load packages"), TypeErrors: typeErrors},
+			expectedCategory:   TypeCheckFailed,
+			expectedTypeErrors: 1,
+		},
+		{
+			name:             "Other failure",
+			err:              errors.New("permission denied"),
+			expectedCategory: AnalysisFailed,
+		},
+	}
+	for _, tt := range tests {
+		actual := newSignatureError(tt.err)
+
+		if actual.Category != tt.expectedCategory {
+			t.Errorf("%q. Expected category %s, but got %s", tt.name, tt.expectedCategory, actual.Category)
+		}
+
+		if actual.Message != tt.err.Error() {
+			t.Errorf("%q. Expected message '%s', but got '%s'", tt.name, tt.err.Error(), actual.Message)
+		}
+
+		if len(actual.TypeErrors) != tt.expectedTypeErrors {
+			t.Errorf("%q. Expected %d type errors, but got %d", tt.name, tt.expectedTypeErrors, len(actual.TypeErrors))
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package diff
+
+import "github.com/a-h/ver/signature"
+
+// Kind is the kind of change made to an exported item.
+type Kind string
+
+const (
+	// Added is used when an item is added.
+	Added Kind = "added"
+	// Removed is used when an item is removed.
+	Removed Kind = "removed"
+	// PlatformAdded is used when an item becomes available on a platform.
+	PlatformAdded Kind = "platformAdded"
+	// PlatformRemoved is used when an item is no longer available on a platform.
+	PlatformRemoved Kind = "platformRemoved"
+	// UnderlyingTypeChanged is used when a named type is defined as a different type, e.g. "type Mode int" to "type Mode string".
+	UnderlyingTypeChanged Kind = "underlyingTypeChanged"
+	// AliasTargetChanged is used when an alias refers to a different type.
+	AliasTargetChanged Kind = "aliasTargetChanged"
+	// AliasToDefinition is used when an alias is replaced by a type definition, e.g. "type A = B" to "type A B".
+	AliasToDefinition Kind = "aliasToDefinition"
+	// DefinitionToAlias is used when a type definition is replaced by an alias, e.g. "type A B" to "type A = B".
+	DefinitionToAlias Kind = "definitionToAlias"
+	// FieldsAdded is used when exported fields are added to a struct.
+	FieldsAdded Kind = "fieldsAdded"
+	// FieldsChanged is used when exported fields are removed from a struct, or their type changes.
+	FieldsChanged Kind = "fieldsChanged"
+	// TagChanged is used when the tag of a struct field changes, e.g. from `json:"name"` to `json:"n"`.
+	TagChanged Kind = "tagChanged"
+	// FieldsReordered is used when the exported fields of a struct are declared in a different order.
+	FieldsReordered Kind = "fieldsReordered"
+	// ComparabilityLost is used when a struct can no longer be compared with == or used as a map key.
+	ComparabilityLost Kind = "comparabilityLost"
+	// ComparabilityGained is used when a struct becomes comparable.
+	ComparabilityGained Kind = "comparabilityGained"
+	// UnexportedFieldsAdded is used when a struct which only had exported fields gains unexported
+	// fields, so it can no longer be created with an unkeyed composite literal.
+	UnexportedFieldsAdded Kind = "unexportedFieldsAdded"
+	// UnexportedFieldsRemoved is used when a struct no longer has unexported fields.
+	UnexportedFieldsRemoved Kind = "unexportedFieldsRemoved"
+	// LayoutChanged is used when the size, alignment or field offsets of a struct change on an
+	// architecture. It doesn't stop code from compiling, but matters to users of cgo, unsafe or
+	// binary encodings.
+	LayoutChanged Kind = "layoutChanged"
+	// ReceiverChanged is used when a method changes from a value receiver to a pointer receiver, or
+	// from a pointer receiver to a value receiver, so it moves between the method sets of T and *T.
+	ReceiverChanged Kind = "receiverChanged"
+	// PromotedAdded is used when a method or field is promoted to a struct from an embedded type.
+	PromotedAdded Kind = "promotedAdded"
+	// PromotedRemoved is used when a method or field is no longer promoted to a struct, e.g. because
+	// it was removed from the embedded type.
+	PromotedRemoved Kind = "promotedRemoved"
+	// PromotedChanged is used when the type of a promoted method or field changes, which is breaking,
+	// or it's promoted from a different embedded type with the same type, which is compatible.
+	PromotedChanged Kind = "promotedChanged"
+	// ImplementationLost is used when an exported type, or a pointer to it, no longer implements an interface.
+	ImplementationLost Kind = "implementationLost"
+	// ImplementationGained is used when an exported type, or a pointer to it, implements a new interface.
+	ImplementationGained Kind = "implementationGained"
+	// ValueChanged is used when the value of a constant changes, but its type doesn't. It doesn't stop
+	// code from compiling, so by default it's compatible, but a policy can version it differently.
+	ValueChanged Kind = "valueChanged"
+	// ConstantTypeChanged is used when the type of a constant changes, e.g. from "untyped int" to "time.Duration".
+	ConstantTypeChanged Kind = "constantTypeChanged"
+	// IotaRenumbered is used when the values of several constants of the same type change, as happens when
+	// a constant is inserted into the middle of an iota block.
+	IotaRenumbered Kind = "iotaRenumbered"
+	// VariableTypeChanged is used when the type of an exported variable changes.
+	VariableTypeChanged Kind = "variableTypeChanged"
+	// SentinelErrorChanged is used when the type of a sentinel error, e.g. "ErrNotFound", changes.
+	SentinelErrorChanged Kind = "sentinelErrorChanged"
+	// SentinelErrorRemoved is used when a sentinel error is removed.
+	SentinelErrorRemoved Kind = "sentinelErrorRemoved"
+	// VarToConst is used when an exported variable is replaced by a constant with the same name.
+	VarToConst Kind = "varToConst"
+	// ConstToVar is used when an exported constant is replaced by a variable with the same name.
+	ConstToVar Kind = "constToVar"
+	// InitializerChanged is used when an exported variable is initialized with a different expression.
+	// It's only reported if the signatures include initializers.
+	InitializerChanged Kind = "initializerChanged"
+	// ParamsAdded is used when parameters are added to the end of a function.
+	ParamsAdded Kind = "paramsAdded"
+	// ParamsRemoved is used when parameters are removed from the end of a function.
+	ParamsRemoved Kind = "paramsRemoved"
+	// ParamsChanged is used when the parameters of a function change in a way that isn't described
+	// by another kind, e.g. they're reordered.
+	ParamsChanged Kind = "paramsChanged"
+	// ParamTypeChanged is used when the type of a parameter changes.
+	ParamTypeChanged Kind = "paramTypeChanged"
+	// ParamWidened is used when the type of a parameter changes to an interface that the previous type implements.
+	ParamWidened Kind = "paramWidened"
+	// VariadicAdded is used when a variadic parameter is added to the end of a function.
+	VariadicAdded Kind = "variadicAdded"
+	// VariadicConversion is used when the last parameter of a function changes from a slice to a
+	// variadic parameter, or the other way around.
+	VariadicConversion Kind = "variadicConversion"
+	// ChannelDirectionChanged is used when a channel parameter or result changes direction, e.g. from "chan int" to "<-chan int".
+	ChannelDirectionChanged Kind = "channelDirectionChanged"
+	// ResultsAdded is used when results are added to a function which didn't return anything.
+	ResultsAdded Kind = "resultsAdded"
+	// ResultsChanged is used when the number of results of a function changes.
+	ResultsChanged Kind = "resultsChanged"
+	// ResultTypeChanged is used when the type of a result changes.
+	ResultTypeChanged Kind = "resultTypeChanged"
+	// FunctionChanged is used when a function changes, but the details of the change aren't known.
+	FunctionChanged Kind = "functionChanged"
+	// Renamed is used when an item is removed, and an item with the same shape is added to the same package with a different name.
+	Renamed Kind = "renamed"
+	// Moved is used when an item is removed, and an item with the same shape is added to another package.
+	Moved Kind = "moved"
+	// StructChanged is used when a struct changes, but the details of the change aren't known.
+	StructChanged Kind = "structChanged"
+	// Deprecated is used when the doc comment of an item gains a "Deprecated: " paragraph.
+	Deprecated Kind = "deprecated"
+)
+
+// Impact is the effect of a change on users of a package.
+type Impact string
+
+const (
+	// Breaking changes can stop code which uses the package from compiling.
+	Breaking Impact = "breaking"
+	// Addition is used for changes which add to the API without breaking existing code.
+	Addition Impact = "addition"
+	// Compatible changes don't add to the API, and don't break existing code.
+	Compatible Impact = "compatible"
+)
+
+// Change describes a change to a single exported item.
+type Change struct {
+	Kind Kind `json:"kind"`
+	// Element is the type of the item, e.g. "functions" or "structs".
+	Element string `json:"element"`
+	// Name is the name of the item, e.g. "Version" or "(*Git).Log".
+	Name     string `json:"name"`
+	Previous string `json:"previous,omitempty"`
+	Current  string `json:"current,omitempty"`
+	Impact   Impact `json:"impact"`
+	// Reason explains the change, and why it has the impact it does.
+	Reason string `json:"reason,omitempty"`
+	// Position is where the item is declared in the next version, or in the previous version
+	// if it was removed. It's nil if the signature doesn't include positions.
+	Position *signature.Position `json:"position,omitempty"`
+}
@@ -0,0 +1,104 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/a-h/ver/signature"
+)
+
+// classifyConstant describes the change to a constant, e.g. from "const a.Timeout untyped int = 10"
+// to "const a.Timeout untyped int = 20".
+func classifyConstant(element string, previous string, current string) []Change {
+	c := Change{
+		Element:  element,
+		Name:     itemName(current),
+		Previous: previous,
+		Current:  current,
+	}
+
+	previousType, previousValue := parseConstant(previous)
+	currentType, currentValue := parseConstant(current)
+
+	if previousType != currentType {
+		c.Kind = ConstantTypeChanged
+		c.Impact = Breaking
+		c.Reason = "type changed from " + previousType + " to " + currentType
+		return []Change{c}
+	}
+
+	c.Kind = ValueChanged
+	c.Impact = Compatible
+	c.Reason = "value changed from " + previousValue + " to " + currentValue
+
+	return []Change{c}
+}
+
+// parseConstant splits a constant rendered by the signature package into its type and value,
+// e.g. "const a.Timeout untyped int = 10" into "untyped int" and "10".
+func parseConstant(item string) (typ string, value string) {
+	rest := strings.TrimPrefix(item, "const ")
+	rest = strings.TrimPrefix(rest[len(identifier(rest)):], " ")
+
+	if i := strings.Index(rest, " = "); i >= 0 {
+		return rest[:i], rest[i+3:]
+	}
+
+	return rest, ""
+}
+
+// detectRenumbering finds constants of the same named type, declared in the same block of
+// constants which uses iota, whose integer values have all changed. This happens when a constant
+// is inserted into, removed from or moved within the block. Renumbering changes the meaning of
+// values which have been stored or sent to other programs.
+func detectRenumbering(changes []Change, next signature.Signature) []Change {
+	byBlock := map[string][]int{}
+
+	for i, c := range changes {
+		if c.Kind != ValueChanged {
+			continue
+		}
+
+		typ, previousValue := parseConstant(c.Previous)
+		_, currentValue := parseConstant(c.Current)
+
+		if !isInteger(previousValue) || !isInteger(currentValue) || !isNamed(typ) {
+			continue
+		}
+
+		block, ok := next.IotaBlocks[c.Name]
+
+		if !ok {
+			continue
+		}
+
+		byBlock[typ+" "+block] = append(byBlock[typ+" "+block], i)
+	}
+
+	for _, indices := range byBlock {
+		if len(indices) < 2 {
+			continue
+		}
+
+		for _, i := range indices {
+			typ, _ := parseConstant(changes[i].Previous)
+			changes[i].Kind = IotaRenumbered
+			changes[i].Impact = Breaking
+			changes[i].Reason += ", along with " + strconv.Itoa(len(indices)-1) + " other constants of type " + typ +
+				", which looks like an iota block was renumbered"
+		}
+	}
+
+	return changes
+}
+
+// isNamed returns true if the type of a constant is a named type, e.g. "a.Color", rather than
+// a basic type, e.g. "int" or "untyped int".
+func isNamed(typ string) bool {
+	return strings.Contains(typ, ".") && !strings.HasPrefix(typ, "untyped ")
+}
+
+func isInteger(s string) bool {
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err == nil
+}
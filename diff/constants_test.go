@@ -0,0 +1,92 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatConstantChangesAreClassified(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  []string
+		next     []string
+		blocks   map[string]string
+		expected Diff
+		kinds    []Kind
+		impacts  []Impact
+	}{
+		{
+			name:     "Value changed",
+			current:  []string{"const a.Timeout untyped int = 10"},
+			next:     []string{"const a.Timeout untyped int = 20"},
+			expected: Diff{Changed: 1},
+			kinds:    []Kind{ValueChanged},
+			impacts:  []Impact{Compatible},
+		},
+		{
+			name:     "Type changed",
+			current:  []string{"const a.Timeout untyped int = 10"},
+			next:     []string{"const a.Timeout time.Duration = 10"},
+			expected: Diff{Changed: 1},
+			kinds:    []Kind{ConstantTypeChanged},
+			impacts:  []Impact{Breaking},
+		},
+		{
+			name:     "Iota renumbered",
+			current:  []string{"const a.Red a.Color = 0", "const a.Green a.Color = 1", "const a.Blue a.Color = 2"},
+			next:     []string{"const a.Red a.Color = 0", "const a.Yellow a.Color = 1", "const a.Green a.Color = 2", "const a.Blue a.Color = 3"},
+			blocks:   map[string]string{"Red": "Red", "Yellow": "Red", "Green": "Red", "Blue": "Red"},
+			expected: Diff{Added: 1, Changed: 2},
+			kinds:    []Kind{IotaRenumbered, IotaRenumbered},
+			impacts:  []Impact{Breaking, Breaking},
+		},
+		{
+			name:     "Unrelated untyped constants changed",
+			current:  []string{"const a.MaxRetries untyped int = 3", "const a.BufferSize untyped int = 1024"},
+			next:     []string{"const a.MaxRetries untyped int = 5", "const a.BufferSize untyped int = 4096"},
+			expected: Diff{Changed: 2},
+			kinds:    []Kind{ValueChanged, ValueChanged},
+			impacts:  []Impact{Compatible, Compatible},
+		},
+		{
+			name:     "Constants of the same type from different blocks changed",
+			current:  []string{"const a.Red a.Color = 0", "const a.Black a.Color = 10"},
+			next:     []string{"const a.Red a.Color = 1", "const a.Black a.Color = 11"},
+			blocks:   map[string]string{"Red": "Red", "Black": "Black"},
+			expected: Diff{Changed: 2},
+			kinds:    []Kind{ValueChanged, ValueChanged},
+			impacts:  []Impact{Compatible, Compatible},
+		},
+		{
+			name:     "Constant renamed",
+			current:  []string{"const a.Timeout untyped int = 10"},
+			next:     []string{"const a.DefaultTimeout untyped int = 10"},
+			expected: Diff{},
+			kinds:    []Kind{Renamed},
+			impacts:  []Impact{Breaking},
+		},
+	}
+
+	for _, tt := range tests {
+		current := signature.PackageSignatures{"a": signature.Signature{Constants: tt.current}}
+		next := signature.PackageSignatures{"a": signature.Signature{Constants: tt.next, IotaBlocks: tt.blocks}}
+
+		actual := Calculate(current, next).Packages[0]
+
+		if actual.Constants != tt.expected {
+			t.Errorf("%q. Expected %v but got %v", tt.name, tt.expected, actual.Constants)
+		}
+
+		if len(actual.Changes) != len(tt.kinds) {
+			t.Errorf("%q. Expected %d changes but got %v", tt.name, len(tt.kinds), actual.Changes)
+			continue
+		}
+
+		for i, c := range actual.Changes {
+			if c.Kind != tt.kinds[i] || c.Impact != tt.impacts[i] {
+				t.Errorf("%q. Expected a %s %s change, but got %v", tt.name, tt.impacts[i], tt.kinds[i], c)
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package diff
+
+import "strings"
+
+// removeCovered removes the additions and removals of items which are already described by a
+// more specific change, e.g. a method which is no longer in the method set of a type because its
+// receiver changed to a pointer, or a variable which was replaced by a constant, so that each
+// change is only counted once. The candidates which are covered are marked as used, so they aren't
+// listed in the Items of the package.
+func removeCovered(d *SummaryDiff, removed []*candidate, added []*candidate) {
+	for i := range d.Packages {
+		pd := &d.Packages[i]
+
+		for _, c := range pd.Changes {
+			named := func(item string) bool { return itemName(item) == c.Name }
+
+			switch c.Kind {
+			case ReceiverChanged:
+				// A method which moved out of the method set of the type is removed from it, and a
+				// method which moved into it is added.
+				if c.Impact == Breaking {
+					pd.Functions.Removed -= cover(removed, pd.PackageName, "functions", named)
+				} else {
+					pd.Functions.Added -= cover(added, pd.PackageName, "functions", named)
+				}
+			case PromotedRemoved:
+				pd.Functions.Removed -= cover(removed, pd.PackageName, "functions", promotedMethod(c.Name))
+			case PromotedAdded:
+				pd.Functions.Added -= cover(added, pd.PackageName, "functions", promotedMethod(c.Name))
+			case VarToConst:
+				pd.Fields.Removed -= cover(removed, pd.PackageName, "fields", isItem(c.Previous))
+				pd.Constants.Added -= cover(added, pd.PackageName, "constants", isItem(c.Current))
+			case ConstToVar:
+				pd.Constants.Removed -= cover(removed, pd.PackageName, "constants", isItem(c.Previous))
+				pd.Fields.Added -= cover(added, pd.PackageName, "fields", isItem(c.Current))
+			}
+		}
+
+		removePromotedMethodChanges(pd)
+	}
+}
+
+// coverSentinelErrors removes the removals of sentinel errors which are reported as a
+// sentinelErrorRemoved change. It's called after detectRenames, since a sentinel error which was
+// renamed or moved to another package is reported as a rename or move instead.
+func coverSentinelErrors(d *SummaryDiff, removed []*candidate) {
+	for i := range d.Packages {
+		pd := &d.Packages[i]
+
+		changes := []Change{}
+
+		for _, c := range pd.Changes {
+			if c.Kind == SentinelErrorRemoved && renamed(removed, pd.PackageName, c.Previous) {
+				continue
+			}
+
+			if c.Kind == SentinelErrorRemoved {
+				pd.Fields.Removed -= cover(removed, pd.PackageName, "fields", isItem(c.Previous))
+			}
+
+			changes = append(changes, c)
+		}
+
+		pd.Changes = changes
+	}
+}
+
+// renamed returns true if the removed item was paired with an added item by detectRenames.
+func renamed(removed []*candidate, pkg string, item string) bool {
+	for _, c := range removed {
+		if c.pkg == pkg && c.element == "fields" && c.item == item {
+			return c.used
+		}
+	}
+
+	return false
+}
+
+// removePromotedMethodChanges removes the changes to methods which are promoted to a struct when
+// the change to the promoted method is reported, e.g. "method Close changed from func() to
+// func() error promoted from Reader (io)".
+func removePromotedMethodChanges(pd *PackageDiff) {
+	covered := map[string]bool{}
+
+	for _, c := range pd.Changes {
+		if c.Kind == PromotedChanged {
+			struc, method := splitPromoted(c.Name)
+			covered["("+struc+")."+method] = true
+			covered["(*"+struc+")."+method] = true
+		}
+	}
+
+	if len(covered) == 0 {
+		return
+	}
+
+	changes := []Change{}
+	removedNames := map[string]bool{}
+
+	for _, c := range pd.Changes {
+		if c.Element == "functions" && c.Kind != ReceiverChanged && covered[c.Name] {
+			removedNames[c.Name] = true
+			continue
+		}
+
+		changes = append(changes, c)
+	}
+
+	pd.Changes = changes
+	pd.Functions.Changed -= len(removedNames)
+}
+
+// promotedMethod returns a function which matches the methods of a struct and a pointer to it,
+// which are promoted from an embedded type, e.g. "method (a.Test) Close() error" for "Test.Close".
+func promotedMethod(name string) func(item string) bool {
+	struc, method := splitPromoted(name)
+
+	return func(item string) bool {
+		n := itemName(item)
+		return n == "("+struc+")."+method || n == "(*"+struc+")."+method
+	}
+}
+
+// splitPromoted splits the name of a promoted method or field, e.g. "Test.Close", into the name
+// of the struct and the promoted method or field.
+func splitPromoted(name string) (string, string) {
+	if i := strings.Index(name, "."); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+
+	return name, ""
+}
+
+// isItem returns a function which matches the item.
+func isItem(i string) func(item string) bool {
+	return func(item string) bool { return item == i }
+}
+
+// cover marks the candidates of the element in the package which match as used, and returns how
+// many were marked.
+func cover(candidates []*candidate, pkg string, element string, matches func(item string) bool) int {
+	count := 0
+
+	for _, c := range candidates {
+		if c.used || c.pkg != pkg || c.element != element || !matches(c.item) {
+			continue
+		}
+
+		c.used = true
+		count++
+	}
+
+	return count
+}
@@ -0,0 +1,51 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/a-h/ver/signature"
+)
+
+// deprecationChanges finds items which exist in both signatures, and have been deprecated.
+// Deprecating an item doesn't stop code from compiling.
+func deprecationChanges(current signature.Signature, next signature.Signature) []Change {
+	var changes []Change
+
+	deprecated := []string{}
+
+	for name := range next.Deprecated {
+		if _, ok := current.Deprecated[name]; !ok {
+			deprecated = append(deprecated, name)
+		}
+	}
+
+	sort.Strings(deprecated)
+
+	currentElements := elements(current)
+
+	for i, e := range elements(next) {
+		currentItems := names(currentElements[i].items)
+		nextItems := names(e.items)
+
+		for _, name := range deprecated {
+			item, inNext := nextItems[name]
+			previous, inCurrent := currentItems[name]
+
+			if !inNext || !inCurrent {
+				continue
+			}
+
+			changes = append(changes, Change{
+				Kind:     Deprecated,
+				Element:  e.name,
+				Name:     name,
+				Previous: previous,
+				Current:  item,
+				Impact:   Compatible,
+				Reason:   "deprecated: " + next.Deprecated[name],
+			})
+		}
+	}
+
+	return changes
+}
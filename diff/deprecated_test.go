@@ -0,0 +1,50 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatDeprecatedItemsAreReported(t *testing.T) {
+	current := signature.Signature{
+		Functions:  []string{"func a.Old()", "func a.Older()", "method (*a.Client) Fetch()"},
+		Deprecated: map[string]string{"Older": "use New."},
+	}
+
+	next := signature.Signature{
+		Functions: []string{"func a.Old()", "func a.Older()", "func a.New()", "method (*a.Client) Fetch()"},
+		Deprecated: map[string]string{
+			"Old":             "use New.",
+			"Older":           "use New.",
+			"New":             "added for completeness.",
+			"(*Client).Fetch": "use Get.",
+		},
+	}
+
+	expected := []Change{
+		{
+			Kind:     Deprecated,
+			Element:  "functions",
+			Name:     "(*Client).Fetch",
+			Previous: "method (*a.Client) Fetch()",
+			Current:  "method (*a.Client) Fetch()",
+			Impact:   Compatible,
+			Reason:   "deprecated: use Get.",
+		},
+		{
+			Kind:     Deprecated,
+			Element:  "functions",
+			Name:     "Old",
+			Previous: "func a.Old()",
+			Current:  "func a.Old()",
+			Impact:   Compatible,
+			Reason:   "deprecated: use New.",
+		},
+	}
+
+	if actual := deprecationChanges(current, next); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
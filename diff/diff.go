@@ -1,6 +1,10 @@
 package diff
 
-import "github.com/a-h/ver/signature"
+import (
+	"sort"
+
+	"github.com/a-h/ver/signature"
+)
 
 // SummaryDiff provides a summary of changes to a set of packages.
 type SummaryDiff struct {
@@ -16,18 +20,31 @@ type PackageDiff struct {
 	Constants   Diff   `json:"constants"`
 	Structs     Diff   `json:"structs"`
 	Interfaces  Diff   `json:"interfaces"`
+	Types       Diff   `json:"types"`
+	// Changes lists the changes to individual items.
+	Changes []Change `json:"changes,omitempty"`
+	// Items lists the items which were added or removed, other than those described by
+	// Changes, e.g. because they were renamed.
+	Items []Change `json:"items,omitempty"`
 }
 
-// Diff describes the changes to an element (added, removed).
+// Diff describes the changes to an element (added, removed, changed).
 type Diff struct {
 	Removed int `json:"removed"`
 	Added   int `json:"added"`
+	// Changed is the number of items which exist in both versions, but are different,
+	// e.g. a type whose underlying type has changed. The Changes of the PackageDiff
+	// describe each one.
+	Changed int `json:"changed"`
 }
 
-// Calculate the difference between package signatures.
+// Calculate the difference between package signatures. Packages are sorted by name, and
+// the changes within each package by element and item name, so that the same signatures
+// always produce the same output.
 func Calculate(current signature.PackageSignatures, next signature.PackageSignatures) SummaryDiff {
 	d := &SummaryDiff{}
-	for currPkgKey, currPkgSig := range current {
+	for _, currPkgKey := range packageNames(current) {
+		currPkgSig := current[currPkgKey]
 		nextPkgSig, ok := next[currPkgKey]
 
 		if !ok {
@@ -36,17 +53,41 @@ func Calculate(current signature.PackageSignatures, next signature.PackageSignat
 			d.PackageChanges.Removed++
 		}
 
+		structs, structChanges := diffStructs(currPkgSig, nextPkgSig)
+		types, typeChanges := diffItems("types", currPkgSig.Types, nextPkgSig.Types, classifyType)
+		constants, constantChanges := diffItems("constants", currPkgSig.Constants, nextPkgSig.Constants, classifyConstant)
+		fields, fieldChanges := diffItems("fields", currPkgSig.Fields, nextPkgSig.Fields, classifyVariable)
+		functions, functionChanges := diffItems("functions", currPkgSig.Functions, nextPkgSig.Functions, functionClassifier(currPkgKey, currPkgSig, nextPkgSig))
+
+		changes := detectRenumbering(constantChanges, nextPkgSig)
+		changes = append(changes, fieldChanges...)
+		changes = append(changes, functionChanges...)
+		changes = append(changes, structChanges...)
+		changes = append(changes, typeChanges...)
+
 		d.Packages = append(d.Packages, PackageDiff{
 			PackageName: currPkgKey,
-			Constants:   calculateStringDiff(currPkgSig.Constants, nextPkgSig.Constants),
-			Fields:      calculateStringDiff(currPkgSig.Fields, nextPkgSig.Fields),
-			Functions:   calculateStringDiff(currPkgSig.Functions, nextPkgSig.Functions),
+			Constants:   constants,
+			Fields:      fields,
+			Functions:   functions,
 			Interfaces:  calculateStringDiff(currPkgSig.Interfaces, nextPkgSig.Interfaces),
-			Structs:     calculateStringDiff(currPkgSig.Structs, nextPkgSig.Structs),
+			Structs:     structs,
+			Types:       types,
+			Changes:     changes,
 		})
+
+		if ok {
+			pd := &d.Packages[len(d.Packages)-1]
+			pd.Changes = append(pd.Changes, variableChanges(currPkgSig, nextPkgSig)...)
+			pd.Changes = append(pd.Changes, methodSetChanges(currPkgSig, nextPkgSig)...)
+			pd.Changes = append(pd.Changes, implementsChanges(currPkgSig, nextPkgSig)...)
+			pd.Changes = append(pd.Changes, platformChanges(currPkgSig, nextPkgSig)...)
+			pd.Changes = append(pd.Changes, deprecationChanges(currPkgSig, nextPkgSig)...)
+		}
 	}
 
-	for nextPkgKey, nextPkgSig := range next {
+	for _, nextPkgKey := range packageNames(next) {
+		nextPkgSig := next[nextPkgKey]
 		_, ok := current[nextPkgKey]
 
 		if ok {
@@ -65,12 +106,98 @@ func Calculate(current signature.PackageSignatures, next signature.PackageSignat
 			Functions:   Diff{Added: len(nextPkgSig.Functions)},
 			Interfaces:  Diff{Added: len(nextPkgSig.Interfaces)},
 			Structs:     Diff{Added: len(nextPkgSig.Structs)},
+			Types:       Diff{Added: len(nextPkgSig.Types)},
 		})
 	}
 
+	removed, added := unpaired(current, next), unpaired(next, current)
+	removeCovered(d, removed, added)
+	detectRenames(d, next, removed, added)
+	coverSentinelErrors(d, removed)
+	addItems(d, removed, added)
+
+	sort.Slice(d.Packages, func(i, j int) bool {
+		return d.Packages[i].PackageName < d.Packages[j].PackageName
+	})
+
+	for _, pd := range d.Packages {
+		addPositions(pd.Changes, current[pd.PackageName], next[pd.PackageName])
+		addPositions(pd.Items, current[pd.PackageName], next[pd.PackageName])
+		sortChanges(pd.Changes)
+		sortChanges(pd.Items)
+	}
+
 	return *d
 }
 
+// addPositions sets the Position of each change to where the item is declared in the next
+// version, or in the current version if it isn't in the next.
+func addPositions(changes []Change, current signature.Signature, next signature.Signature) {
+	for i, c := range changes {
+		if p, ok := next.Positions[c.Current]; ok {
+			changes[i].Position = &p
+			continue
+		}
+
+		if p, ok := current.Positions[c.Previous]; ok {
+			changes[i].Position = &p
+		}
+	}
+}
+
+// packagesByName returns a pointer to the diff of each package, by name.
+func packagesByName(d *SummaryDiff) map[string]*PackageDiff {
+	rv := make(map[string]*PackageDiff, len(d.Packages))
+
+	for i := range d.Packages {
+		rv[d.Packages[i].PackageName] = &d.Packages[i]
+	}
+
+	return rv
+}
+
+// packageNames returns the names of the packages in sorted order.
+func packageNames(ps signature.PackageSignatures) []string {
+	rv := make([]string, 0, len(ps))
+
+	for name := range ps {
+		rv = append(rv, name)
+	}
+
+	sort.Strings(rv)
+
+	return rv
+}
+
+// sortChanges sorts changes by element and item name. Changes to the same item keep the
+// order they were found in.
+func sortChanges(changes []Change) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Element != changes[j].Element {
+			return changes[i].Element < changes[j].Element
+		}
+
+		return changes[i].Name < changes[j].Name
+	})
+}
+
+type element struct {
+	name  string
+	items []string
+}
+
+// elements returns the items of the signature, along with the name of each type of item.
+func elements(s signature.Signature) []element {
+	return []element{
+		{name: "functions", items: s.Functions},
+		{name: "fields", items: s.Fields},
+		{name: "constants", items: s.Constants},
+		{name: "structs", items: s.Structs},
+		{name: "interfaces", items: s.Interfaces},
+		{name: "types", items: s.Types},
+	}
+}
+
 func calculateStringDiff(current []string, next []string) Diff {
 	c := makeStringMap(current)
 	n := makeStringMap(next)
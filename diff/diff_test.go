@@ -196,10 +196,46 @@ func TestCalculate(t *testing.T) {
 			testAreEqual(tt.name, i, "Functions", act.Functions, exp.Functions, t)
 			testAreEqual(tt.name, i, "Interfaces", act.Interfaces, exp.Interfaces, t)
 			testAreEqual(tt.name, i, "Structs", act.Structs, exp.Structs, t)
+			testAreEqual(tt.name, i, "Types", act.Types, exp.Types, t)
 		}
 	}
 }
 
+func TestThatTheDiffIsSortedAndStable(t *testing.T) {
+	current := signature.PackageSignatures{
+		"packageC": signature.Signature{Functions: []string{"func c.B()", "func c.A()"}},
+		"packageA": signature.Signature{Types: []string{"type a.B int", "type a.A int"}},
+	}
+
+	next := signature.PackageSignatures{
+		"packageC": signature.Signature{Functions: []string{"func c.A(int)", "func c.B(int)"}},
+		"packageA": signature.Signature{Types: []string{"type a.A string", "type a.B string"}},
+		"packageB": signature.Signature{Functions: []string{"func b.A()"}},
+	}
+
+	expected := Calculate(current, next)
+
+	for i := 0; i < 10; i++ {
+		if actual := Calculate(current, next); !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected the diff to be the same each time, but got %v and %v", expected, actual)
+		}
+	}
+
+	var packages []string
+
+	for _, pd := range expected.Packages {
+		packages = append(packages, pd.PackageName)
+
+		if len(pd.Changes) == 2 && pd.Changes[0].Name != "A" {
+			t.Errorf("%s: expected the changes to be sorted by name, but got %v", pd.PackageName, pd.Changes)
+		}
+	}
+
+	if !reflect.DeepEqual(packages, []string{"packageA", "packageB", "packageC"}) {
+		t.Errorf("expected the packages to be sorted, but got %v", packages)
+	}
+}
+
 func testAreEqual(testName string, pkgIndex int, field string, actual Diff, expected Diff, t *testing.T) {
 	if expected.Added != actual.Added {
 		t.Errorf("%q. Package index %d: Expected %d %s added, but %d were found to have been added", testName, pkgIndex, expected.Added, field, actual.Added)
@@ -208,6 +244,10 @@ func testAreEqual(testName string, pkgIndex int, field string, actual Diff, expe
 	if expected.Removed != actual.Removed {
 		t.Errorf("%q. Package index %d: Expected %d %s removed, but %d were found to have been removed", testName, pkgIndex, expected.Removed, field, actual.Removed)
 	}
+
+	if expected.Changed != actual.Changed {
+		t.Errorf("%q. Package index %d: Expected %d %s changed, but %d were found to have been changed", testName, pkgIndex, expected.Changed, field, actual.Changed)
+	}
 }
 
 func max(a int, b int) int {
@@ -0,0 +1,250 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/a-h/ver/signature"
+)
+
+// functionClassifier describes the changes to functions and methods, using the parameters and
+// results of each function in the signatures of the package.
+func functionClassifier(pkg string, current signature.Signature, next signature.Signature) classifier {
+	return func(element string, previous string, curr string) []Change {
+		base := Change{
+			Element:  element,
+			Name:     itemName(curr),
+			Previous: previous,
+			Current:  curr,
+		}
+
+		p, pok := current.Funcs[base.Name]
+		n, nok := next.Funcs[base.Name]
+
+		if pok && nok {
+			f := funcComparison{
+				base:     base,
+				method:   strings.HasPrefix(curr, "method "),
+				pkg:      pkg,
+				previous: current,
+			}
+
+			if f.sameTypes(p, n) {
+				// Only the names of the parameters or results have changed.
+				return nil
+			}
+
+			if changes := f.compare(p, n); len(changes) > 0 {
+				return changes
+			}
+		}
+
+		// Signatures saved by older versions, and approximate signatures, don't have the details of each function.
+		base.Kind = FunctionChanged
+		base.Impact = Breaking
+		base.Reason = "signature changed"
+
+		return []Change{base}
+	}
+}
+
+type funcComparison struct {
+	base Change
+	// method is true when the function is a method. Changes which are compatible for the callers of a
+	// function still break types which implement an interface with the method.
+	method bool
+	// pkg is the path of the package the function is in.
+	pkg string
+	// previous is the signature of the package the function was in, used to find the interfaces
+	// that the types of parameters implement.
+	previous signature.Signature
+}
+
+func (f funcComparison) sameTypes(p signature.Func, n signature.Func) bool {
+	return p.Variadic == n.Variadic && paramTypes(p.Params) == paramTypes(n.Params) && paramTypes(p.Results) == paramTypes(n.Results)
+}
+
+// compare lists the changes to the parameters and results of a function.
+func (f funcComparison) compare(p signature.Func, n signature.Func) []Change {
+	var changes []Change
+
+	changes = append(changes, f.compareParams(p, n)...)
+	changes = append(changes, f.compareResults(p.Results, n.Results)...)
+
+	return changes
+}
+
+func (f funcComparison) compareParams(p signature.Func, n signature.Func) []Change {
+	np, nn := len(p.Params), len(n.Params)
+
+	switch {
+	case !p.Variadic && n.Variadic && nn == np+1 && paramTypes(p.Params) == paramTypes(n.Params[:np]):
+		return []Change{f.change(VariadicAdded, Addition,
+			"variadic parameter "+n.Params[nn-1].Name+" added, so existing calls still compile")}
+	case p.Variadic != n.Variadic && nn == np && paramTypes(p.Params) == paramTypes(n.Params):
+		if n.Variadic {
+			return []Change{f.change(VariadicConversion, Breaking,
+				"parameter "+n.Params[nn-1].Name+" is now variadic, so callers which pass a slice must add ...")}
+		}
+		return []Change{f.change(VariadicConversion, Breaking,
+			"parameter "+n.Params[nn-1].Name+" is no longer variadic, so callers must pass a slice")}
+	case p.Variadic != n.Variadic:
+		return []Change{f.change(ParamsChanged, Breaking, "parameters changed from "+paramTypes(p.Params)+" to "+paramTypes(n.Params))}
+	case nn > np && paramTypes(p.Params) == paramTypes(n.Params[:np]):
+		return []Change{f.change(ParamsAdded, Breaking, "parameters added: "+paramTypes(n.Params[np:]))}
+	case nn < np && paramTypes(p.Params[:nn]) == paramTypes(n.Params):
+		return []Change{f.change(ParamsRemoved, Breaking, "parameters removed: "+paramTypes(p.Params[nn:]))}
+	case nn != np:
+		return []Change{f.change(ParamsChanged, Breaking, "parameters changed from "+paramTypes(p.Params)+" to "+paramTypes(n.Params))}
+	}
+
+	var changes []Change
+
+	for i := range p.Params {
+		pp, np := p.Params[i], n.Params[i]
+
+		if pp.Type == np.Type {
+			continue
+		}
+
+		name := "parameter " + strconv.Itoa(i+1)
+
+		if np.Name != "" {
+			name = "parameter " + np.Name
+		}
+
+		switch {
+		case sameChannel(pp.Type, np.Type):
+			// A bidirectional channel can be passed where a directional channel is expected.
+			impact := Breaking
+			if strings.HasPrefix(pp.Type, "chan ") {
+				impact = Compatible
+			}
+			changes = append(changes, f.change(ChannelDirectionChanged, impact, name+" changed from "+pp.Type+" to "+np.Type))
+		case np.Interface && f.implements(pp, np.Type):
+			changes = append(changes, f.change(ParamWidened, Compatible,
+				name+" widened from "+pp.Type+" to "+np.Type+", which "+pp.Type+" implements, so existing calls still compile"))
+		default:
+			changes = append(changes, f.change(ParamTypeChanged, Breaking, name+" changed from "+pp.Type+" to "+np.Type))
+		}
+	}
+
+	return changes
+}
+
+func (f funcComparison) compareResults(p []signature.Param, n []signature.Param) []Change {
+	if len(p) == 0 && len(n) > 0 {
+		return []Change{f.change(ResultsAdded, Addition,
+			"results added: "+paramTypes(n)+", calls which ignore the results still compile")}
+	}
+
+	if len(p) != len(n) {
+		return []Change{f.change(ResultsChanged, Breaking, "results changed from "+paramTypes(p)+" to "+paramTypes(n))}
+	}
+
+	var changes []Change
+
+	for i := range p {
+		if p[i].Type == n[i].Type {
+			continue
+		}
+
+		reason := "result " + strconv.Itoa(i+1) + " changed from " + p[i].Type + " to " + n[i].Type
+
+		if sameChannel(p[i].Type, n[i].Type) {
+			// A bidirectional channel can be used where a directional channel was returned.
+			impact := Breaking
+			if strings.HasPrefix(n[i].Type, "chan ") {
+				impact = Compatible
+			}
+			changes = append(changes, f.change(ChannelDirectionChanged, impact, reason))
+			continue
+		}
+
+		changes = append(changes, f.change(ResultTypeChanged, Breaking, reason))
+	}
+
+	return changes
+}
+
+// change creates a change to the function. Methods can't change in a compatible way, because types
+// which implement an interface with the method would no longer implement it.
+func (f funcComparison) change(kind Kind, impact Impact, reason string) Change {
+	c := f.base
+	c.Kind = kind
+	c.Impact = impact
+	c.Reason = reason
+
+	if f.method && impact != Breaking {
+		c.Impact = Breaking
+		c.Reason += ", but the method no longer matches interfaces which require the previous signature"
+	}
+
+	return c
+}
+
+// implements returns true if the type of the parameter implements the interface, e.g. "any", or an
+// interface which the parameter was recorded as implementing. Signatures read with GetFromScope
+// don't record the interfaces of each parameter, so the interfaces which the previous version of the
+// function's package recorded its own types as implementing are used too.
+func (f funcComparison) implements(p signature.Param, iface string) bool {
+	if iface == "any" || iface == "interface{}" {
+		return true
+	}
+
+	for _, i := range p.Implements {
+		if i == iface {
+			return true
+		}
+	}
+
+	typ := p.Type
+	pointer := ""
+
+	if strings.HasPrefix(typ, "*") {
+		pointer = "*"
+	}
+
+	name := strings.TrimPrefix(strings.TrimPrefix(typ, "*"), f.pkg+".")
+
+	if name == strings.TrimPrefix(typ, "*") || strings.ContainsAny(name, "./[") {
+		return false
+	}
+
+	for _, i := range f.previous.Implements[pointer+name] {
+		if i == iface {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameChannel returns true if both types are channels of the same element type, e.g. "chan int" and "<-chan int".
+func sameChannel(a string, b string) bool {
+	ae, aok := channelElement(a)
+	be, bok := channelElement(b)
+
+	return aok && bok && ae == be
+}
+
+func channelElement(t string) (string, bool) {
+	for _, prefix := range []string{"chan<- ", "<-chan ", "chan "} {
+		if strings.HasPrefix(t, prefix) {
+			return strings.TrimPrefix(t, prefix), true
+		}
+	}
+
+	return "", false
+}
+
+// paramTypes renders the types of the parameters, e.g. "(string, int)".
+func paramTypes(params []signature.Param) string {
+	types := []string{}
+
+	for _, p := range params {
+		types = append(types, p.Type)
+	}
+
+	return "(" + strings.Join(types, ", ") + ")"
+}
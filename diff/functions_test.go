@@ -0,0 +1,172 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatFunctionChangesAreClassified(t *testing.T) {
+	str := signature.Param{Name: "s", Type: "string"}
+	strs := signature.Param{Name: "values", Type: "[]string"}
+	err := signature.Param{Type: "error", Interface: true}
+
+	tests := []struct {
+		name     string
+		previous signature.Func
+		current  signature.Func
+		method   bool
+		kinds    []Kind
+		impacts  []Impact
+	}{
+		{
+			name:     "Parameter renamed",
+			previous: signature.Func{Params: []signature.Param{str}},
+			current:  signature.Func{Params: []signature.Param{{Name: "value", Type: "string"}}},
+		},
+		{
+			name:     "Parameter added",
+			previous: signature.Func{Params: []signature.Param{str}},
+			current:  signature.Func{Params: []signature.Param{str, {Name: "n", Type: "int"}}},
+			kinds:    []Kind{ParamsAdded},
+			impacts:  []Impact{Breaking},
+		},
+		{
+			name:     "Parameter removed",
+			previous: signature.Func{Params: []signature.Param{str, {Name: "n", Type: "int"}}},
+			current:  signature.Func{Params: []signature.Param{str}},
+			kinds:    []Kind{ParamsRemoved},
+			impacts:  []Impact{Breaking},
+		},
+		{
+			name:     "Variadic parameter added",
+			previous: signature.Func{Params: []signature.Param{str}},
+			current:  signature.Func{Params: []signature.Param{str, strs}, Variadic: true},
+			kinds:    []Kind{VariadicAdded},
+			impacts:  []Impact{Addition},
+		},
+		{
+			name:     "Variadic parameter added to a method",
+			previous: signature.Func{Params: []signature.Param{str}},
+			current:  signature.Func{Params: []signature.Param{str, strs}, Variadic: true},
+			method:   true,
+			kinds:    []Kind{VariadicAdded},
+			impacts:  []Impact{Breaking},
+		},
+		{
+			name:     "Slice converted to variadic",
+			previous: signature.Func{Params: []signature.Param{strs}},
+			current:  signature.Func{Params: []signature.Param{strs}, Variadic: true},
+			kinds:    []Kind{VariadicConversion},
+			impacts:  []Impact{Breaking},
+		},
+		{
+			name:     "Channel parameter made receive only",
+			previous: signature.Func{Params: []signature.Param{{Name: "c", Type: "chan int"}}},
+			current:  signature.Func{Params: []signature.Param{{Name: "c", Type: "<-chan int"}}},
+			kinds:    []Kind{ChannelDirectionChanged},
+			impacts:  []Impact{Compatible},
+		},
+		{
+			name:     "Channel result made receive only",
+			previous: signature.Func{Results: []signature.Param{{Type: "chan int"}}},
+			current:  signature.Func{Results: []signature.Param{{Type: "<-chan int"}}},
+			kinds:    []Kind{ChannelDirectionChanged},
+			impacts:  []Impact{Breaking},
+		},
+		{
+			name:     "Parameter widened to an implemented interface",
+			previous: signature.Func{Params: []signature.Param{{Name: "f", Type: "*a.File"}}},
+			current:  signature.Func{Params: []signature.Param{{Name: "f", Type: "io.Reader", Interface: true}}},
+			kinds:    []Kind{ParamWidened},
+			impacts:  []Impact{Compatible},
+		},
+		{
+			name:     "Parameter changed to an interface which isn't implemented",
+			previous: signature.Func{Params: []signature.Param{{Name: "f", Type: "*a.File"}}},
+			current:  signature.Func{Params: []signature.Param{{Name: "f", Type: "io.Writer", Interface: true}}},
+			kinds:    []Kind{ParamTypeChanged},
+			impacts:  []Impact{Breaking},
+		},
+		{
+			name:     "Parameter of a type from another package widened to an implemented interface",
+			previous: signature.Func{Params: []signature.Param{{Name: "r", Type: "*bytes.Buffer", Implements: []string{"io.Reader", "io.Writer"}}}},
+			current:  signature.Func{Params: []signature.Param{{Name: "r", Type: "io.Reader", Interface: true}}},
+			kinds:    []Kind{ParamWidened},
+			impacts:  []Impact{Compatible},
+		},
+		{
+			name:     "Parameter of a type from another package with the same name changed to an interface",
+			previous: signature.Func{Params: []signature.Param{{Name: "f", Type: "*b.File"}}},
+			current:  signature.Func{Params: []signature.Param{{Name: "f", Type: "io.Reader", Interface: true}}},
+			kinds:    []Kind{ParamTypeChanged},
+			impacts:  []Impact{Breaking},
+		},
+		{
+			name:     "Result added",
+			previous: signature.Func{},
+			current:  signature.Func{Results: []signature.Param{err}},
+			kinds:    []Kind{ResultsAdded},
+			impacts:  []Impact{Addition},
+		},
+		{
+			name:     "Result type changed",
+			previous: signature.Func{Results: []signature.Param{str, err}},
+			current:  signature.Func{Results: []signature.Param{{Type: "int"}, err}},
+			kinds:    []Kind{ResultTypeChanged},
+			impacts:  []Impact{Breaking},
+		},
+	}
+
+	for _, tt := range tests {
+		previousItem, currentItem, name := "func a.F(previous)", "func a.F(current)", "F"
+
+		if tt.method {
+			previousItem, currentItem, name = "method (*a.File) F(previous)", "method (*a.File) F(current)", "(*File).F"
+		}
+
+		current := signature.PackageSignatures{"a": signature.Signature{
+			Functions:  []string{previousItem},
+			Funcs:      map[string]signature.Func{name: tt.previous},
+			Implements: map[string][]string{"*File": []string{"io.Reader"}},
+		}}
+		next := signature.PackageSignatures{"a": signature.Signature{
+			Functions: []string{currentItem},
+			Funcs:     map[string]signature.Func{name: tt.current},
+		}}
+
+		actual := Calculate(current, next).Packages[0]
+
+		if actual.Functions.Added != 0 || actual.Functions.Removed != 0 || actual.Functions.Changed != len(tt.kinds) {
+			t.Errorf("%q. Expected %d changed functions, but got %v", tt.name, len(tt.kinds), actual.Functions)
+		}
+
+		if len(actual.Changes) != len(tt.kinds) {
+			t.Errorf("%q. Expected %d changes but got %v", tt.name, len(tt.kinds), actual.Changes)
+			continue
+		}
+
+		for i, c := range actual.Changes {
+			if c.Kind != tt.kinds[i] || c.Impact != tt.impacts[i] {
+				t.Errorf("%q. Expected a %s %s change, but got %v", tt.name, tt.impacts[i], tt.kinds[i], c)
+			}
+		}
+	}
+}
+
+func TestThatMethodsOnGenericTypesAreClassifiedUsingTheirDetails(t *testing.T) {
+	current := signature.PackageSignatures{"a": signature.Signature{
+		Functions: []string{"method (*a.List[T any]) Push(v T)"},
+		Funcs:     map[string]signature.Func{"(*List).Push": {Params: []signature.Param{{Name: "v", Type: "T"}}}},
+	}}
+	next := signature.PackageSignatures{"a": signature.Signature{
+		Functions: []string{"method (*a.List[T any]) Push(value T)"},
+		Funcs:     map[string]signature.Func{"(*List).Push": {Params: []signature.Param{{Name: "value", Type: "T"}}}},
+	}}
+
+	actual := Calculate(current, next).Packages[0]
+
+	if actual.Functions.Changed != 0 || len(actual.Changes) != 0 {
+		t.Errorf("expected renaming a parameter not to change the method, but got %v", actual.Changes)
+	}
+}
@@ -0,0 +1,63 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/a-h/ver/signature"
+)
+
+// implementsChanges finds exported types which implement different interfaces. Types which have been
+// removed aren't included, since their removal is already a breaking change.
+func implementsChanges(current signature.Signature, next signature.Signature) []Change {
+	var changes []Change
+
+	types := []string{}
+
+	for t := range current.Implements {
+		types = append(types, t)
+	}
+
+	for t := range next.Implements {
+		if _, ok := current.Implements[t]; !ok {
+			types = append(types, t)
+		}
+	}
+
+	sort.Strings(types)
+
+	for _, t := range types {
+		name := strings.TrimPrefix(t, "*")
+
+		_, inCurrent := current.MethodSets[name]
+		_, inNext := next.MethodSets[name]
+
+		if !inCurrent || !inNext {
+			continue
+		}
+
+		previous, now := current.Implements[t], next.Implements[t]
+
+		for _, i := range difference(previous, now) {
+			changes = append(changes, Change{
+				Kind:    ImplementationLost,
+				Element: "types",
+				Name:    t,
+				Impact:  Breaking,
+				Reason:  t + " no longer implements " + i,
+			})
+		}
+
+		for _, i := range difference(now, previous) {
+			changes = append(changes, Change{
+				Kind:    ImplementationGained,
+				Element: "types",
+				Name:    t,
+				Impact:  Addition,
+				Reason:  t + " now implements " + i,
+			})
+		}
+	}
+
+	return changes
+}
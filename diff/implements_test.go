@@ -0,0 +1,43 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatLostImplementationsAreBreaking(t *testing.T) {
+	current := signature.PackageSignatures{"a": signature.Signature{
+		MethodSets: map[string]signature.MethodSet{"File": {}, "Removed": {}},
+		Implements: map[string][]string{
+			"*File":   []string{"io.Closer", "io.Reader"},
+			"Removed": []string{"error"},
+		},
+	}}
+	next := signature.PackageSignatures{"a": signature.Signature{
+		MethodSets: map[string]signature.MethodSet{"File": {}},
+		Implements: map[string][]string{
+			"File":  []string{"fmt.Stringer"},
+			"*File": []string{"fmt.Stringer", "io.Reader"},
+		},
+	}}
+
+	changes := Calculate(current, next).Packages[0].Changes
+
+	expected := []Change{
+		{Kind: ImplementationLost, Name: "*File", Impact: Breaking, Reason: "*File no longer implements io.Closer"},
+		{Kind: ImplementationGained, Name: "*File", Impact: Addition, Reason: "*File now implements fmt.Stringer"},
+		{Kind: ImplementationGained, Name: "File", Impact: Addition, Reason: "File now implements fmt.Stringer"},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %v, but got %v", expected, changes)
+	}
+
+	for i, c := range changes {
+		e := expected[i]
+		if c.Kind != e.Kind || c.Name != e.Name || c.Impact != e.Impact || c.Reason != e.Reason {
+			t.Errorf("expected %v, but got %v", e, c)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package diff
+
+// classifier describes the changes between two versions of an item which have the same name.
+type classifier func(element string, previous string, current string) []Change
+
+// diffItems compares the items of an element. An item which is removed and added with
+// the same name is counted as changed, and described by classify, rather than being
+// counted as a removal and an addition. If classify doesn't return any changes, the
+// item isn't counted at all.
+func diffItems(element string, current []string, next []string, classify classifier) (Diff, []Change) {
+	removed := difference(current, next)
+	added := difference(next, current)
+
+	addedByName := make(map[string]string, len(added))
+
+	for _, item := range added {
+		addedByName[itemName(item)] = item
+	}
+
+	d := Diff{}
+	var changes []Change
+	paired := map[string]bool{}
+
+	for _, item := range removed {
+		name := itemName(item)
+		a, ok := addedByName[name]
+
+		if !ok || paired[name] {
+			d.Removed++
+			continue
+		}
+
+		paired[name] = true
+
+		// Changes which don't affect users, e.g. renaming a parameter, aren't counted.
+		if c := classify(element, item, a); len(c) > 0 {
+			d.Changed++
+			changes = append(changes, c...)
+		}
+	}
+
+	d.Added = len(added) - len(paired)
+
+	return d, changes
+}
+
+// addItems lists each of the candidates which wasn't paired by detectRenames in the Items
+// of its package.
+func addItems(d *SummaryDiff, removed []*candidate, added []*candidate) {
+	packages := packagesByName(d)
+
+	for _, r := range removed {
+		if r.used {
+			continue
+		}
+
+		pd := packages[r.pkg]
+		pd.Items = append(pd.Items, Change{
+			Kind:     Removed,
+			Element:  r.element,
+			Name:     itemName(r.item),
+			Previous: r.item,
+			Impact:   Breaking,
+		})
+	}
+
+	for _, a := range added {
+		if a.used {
+			continue
+		}
+
+		pd := packages[a.pkg]
+		pd.Items = append(pd.Items, Change{
+			Kind:    Added,
+			Element: a.element,
+			Name:    itemName(a.item),
+			Current: a.item,
+			Impact:  Addition,
+		})
+	}
+}
+
+// difference returns the items of a which aren't in b.
+func difference(a []string, b []string) []string {
+	inB := makeStringMap(b)
+	rv := []string{}
+
+	for _, item := range a {
+		if !inB[item] {
+			rv = append(rv, item)
+		}
+	}
+
+	return rv
+}
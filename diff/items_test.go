@@ -0,0 +1,74 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatAddedAndRemovedItemsAreListed(t *testing.T) {
+	current := signature.PackageSignatures{
+		"a": signature.Signature{
+			Functions: []string{"func a.Get() string", "func a.Old(s string)", "func a.Same()"},
+			Structs:   []string{"struct Client { field URL string }"},
+		},
+		"b": signature.Signature{
+			Constants: []string{"const b.X untyped int = 1"},
+		},
+	}
+	next := signature.PackageSignatures{
+		"a": signature.Signature{
+			Functions: []string{"func a.Get() int", "func a.New() error", "func a.Same()"},
+			Structs:   []string{"struct HTTPClient { field URL string }"},
+		},
+	}
+
+	d := Calculate(current, next)
+
+	expected := map[string][]Change{
+		"a": []Change{
+			{Kind: Added, Element: "functions", Name: "New", Current: "func a.New() error", Impact: Addition},
+			{Kind: Removed, Element: "functions", Name: "Old", Previous: "func a.Old(s string)", Impact: Breaking},
+		},
+		"b": []Change{
+			{Kind: Removed, Element: "constants", Name: "X", Previous: "const b.X untyped int = 1", Impact: Breaking},
+		},
+	}
+
+	for _, pd := range d.Packages {
+		if !reflect.DeepEqual(pd.Items, expected[pd.PackageName]) {
+			t.Errorf("%s: expected items %v, but got %v", pd.PackageName, expected[pd.PackageName], pd.Items)
+		}
+	}
+}
+
+func TestThatChangesIncludeWhereTheItemIsDeclared(t *testing.T) {
+	current := signature.PackageSignatures{
+		"a": signature.Signature{
+			Functions: []string{"func a.Get() string", "func a.Old(s string)"},
+			Positions: map[string]signature.Position{
+				"func a.Get() string":  {File: "a.go", Line: 3},
+				"func a.Old(s string)": {File: "old.go", Line: 5},
+			},
+		},
+	}
+	next := signature.PackageSignatures{
+		"a": signature.Signature{
+			Functions: []string{"func a.Get() int"},
+			Positions: map[string]signature.Position{
+				"func a.Get() int": {File: "a.go", Line: 4},
+			},
+		},
+	}
+
+	pd := Calculate(current, next).Packages[0]
+
+	if len(pd.Changes) == 0 || pd.Changes[0].Position == nil || *pd.Changes[0].Position != (signature.Position{File: "a.go", Line: 4}) {
+		t.Errorf("expected the change to Get to be at its position in the next version, but got %v", pd.Changes)
+	}
+
+	if len(pd.Items) != 1 || pd.Items[0].Position == nil || *pd.Items[0].Position != (signature.Position{File: "old.go", Line: 5}) {
+		t.Errorf("expected the removal of Old to be at its position in the previous version, but got %v", pd.Items)
+	}
+}
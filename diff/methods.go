@@ -0,0 +1,54 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/a-h/ver/signature"
+)
+
+// methodSetChanges finds methods which have moved between the method sets of a type T and *T,
+// because their receiver changed from a value to a pointer, or from a pointer to a value.
+func methodSetChanges(current signature.Signature, next signature.Signature) []Change {
+	var changes []Change
+
+	names := []string{}
+
+	for name := range next.MethodSets {
+		if _, ok := current.MethodSets[name]; ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		p, n := current.MethodSets[name], next.MethodSets[name]
+
+		for _, method := range p.Value {
+			if !n.HasValue(method) && n.HasPointer(method) {
+				changes = append(changes, Change{
+					Kind:    ReceiverChanged,
+					Element: "functions",
+					Name:    "(" + name + ")." + method,
+					Impact:  Breaking,
+					Reason: "method " + method + " now has a pointer receiver, so it's no longer in the method set of " + name +
+						", and " + name + " no longer satisfies interfaces which require it",
+				})
+			}
+		}
+
+		for _, method := range n.Value {
+			if !p.HasValue(method) && p.HasPointer(method) {
+				changes = append(changes, Change{
+					Kind:    ReceiverChanged,
+					Element: "functions",
+					Name:    "(" + name + ")." + method,
+					Impact:  Addition,
+					Reason:  "method " + method + " now has a value receiver, so it's in the method set of " + name + " as well as *" + name,
+				})
+			}
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,63 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatReceiverChangesAreReported(t *testing.T) {
+	current := signature.PackageSignatures{"a": signature.Signature{
+		MethodSets: map[string]signature.MethodSet{
+			"Test": {Value: []string{"A", "C"}, Pointer: []string{"A", "B", "C"}},
+		},
+	}}
+	next := signature.PackageSignatures{"a": signature.Signature{
+		MethodSets: map[string]signature.MethodSet{
+			"Test": {Value: []string{"B", "C"}, Pointer: []string{"A", "B", "C"}},
+		},
+	}}
+
+	changes := Calculate(current, next).Packages[0].Changes
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, but got %v", changes)
+	}
+
+	if changes[0].Kind != ReceiverChanged || changes[0].Name != "(Test).A" || changes[0].Impact != Breaking {
+		t.Errorf("expected A to move to a pointer receiver, but got %v", changes[0])
+	}
+
+	if changes[1].Kind != ReceiverChanged || changes[1].Name != "(Test).B" || changes[1].Impact != Addition {
+		t.Errorf("expected B to move to a value receiver, but got %v", changes[1])
+	}
+}
+
+func TestThatMethodsWhichChangedReceiverAreNotAlsoCountedAsAddedOrRemoved(t *testing.T) {
+	current := signature.PackageSignatures{"a": signature.Signature{
+		Functions: []string{"method (*a.Test) A()", "method (*a.Test) B()", "method (a.Test) A()"},
+		MethodSets: map[string]signature.MethodSet{
+			"Test": {Value: []string{"A"}, Pointer: []string{"A", "B"}},
+		},
+	}}
+	next := signature.PackageSignatures{"a": signature.Signature{
+		Functions: []string{"method (*a.Test) A()", "method (*a.Test) B()", "method (a.Test) B()"},
+		MethodSets: map[string]signature.MethodSet{
+			"Test": {Value: []string{"B"}, Pointer: []string{"A", "B"}},
+		},
+	}}
+
+	pd := Calculate(current, next).Packages[0]
+
+	if pd.Functions != (Diff{}) {
+		t.Errorf("expected the functions not to be counted as added or removed, but got %v", pd.Functions)
+	}
+
+	if len(pd.Items) != 0 {
+		t.Errorf("expected no items to be listed as added or removed, but got %v", pd.Items)
+	}
+
+	if len(pd.Changes) != 2 {
+		t.Errorf("expected the 2 receiver changes, but got %v", pd.Changes)
+	}
+}
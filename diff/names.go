@@ -0,0 +1,46 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/a-h/ver/signature"
+)
+
+// itemName gets the name of an item rendered by the signature package, without
+// its package path, e.g. "func github.com/a-h/ver/git.Clone(repo string) (Git, error)"
+// is named "Clone", and "method (*github.com/a-h/ver/git.Git) Log()" is named "(*Git).Log". Methods
+// are named by signature.MethodName, so that they match the keys of the details of each function.
+func itemName(item string) string {
+	switch {
+	case strings.HasPrefix(item, "method ("):
+		rest := strings.TrimPrefix(item, "method (")
+		end := strings.Index(rest, ") ")
+		if end < 0 {
+			return item
+		}
+		return signature.MethodName(rest[:end], identifier(rest[end+2:]))
+	case strings.HasPrefix(item, "func "),
+		strings.HasPrefix(item, "var "),
+		strings.HasPrefix(item, "const "),
+		strings.HasPrefix(item, "struct "),
+		strings.HasPrefix(item, "type "):
+		return unqualified(identifier(item[strings.Index(item, " ")+1:]))
+	}
+
+	return unqualified(identifier(item))
+}
+
+// identifier returns the qualified identifier at the start of s, e.g. "github.com/a-h/ver.Version"
+// from "github.com/a-h/ver.Version int".
+func identifier(s string) string {
+	if end := strings.IndexAny(s, " ([{"); end >= 0 {
+		return s[:end]
+	}
+
+	return s
+}
+
+// unqualified removes the package path from a qualified identifier.
+func unqualified(s string) string {
+	return s[strings.LastIndex(s, ".")+1:]
+}
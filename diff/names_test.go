@@ -0,0 +1,53 @@
+package diff
+
+import "testing"
+
+func TestThatItemNamesCanBeExtracted(t *testing.T) {
+	tests := []struct {
+		item     string
+		expected string
+	}{
+		{
+			item:     "func github.com/a-h/ver/git.Clone(repo string) (github.com/a-h/ver/git.Git, error)",
+			expected: "Clone",
+		},
+		{
+			item:     "method (*github.com/a-h/ver/git.Git) Log() ([]github.com/a-h/ver/git.Commit, error)",
+			expected: "(*Git).Log",
+		},
+		{
+			item:     "method (github.com/a-h/ver/git.Commit) Date() time.Time",
+			expected: "(Commit).Date",
+		},
+		{
+			item:     "method (*github.com/a-h/example.List[T any]) Push(v T)",
+			expected: "(*List).Push",
+		},
+		{
+			item:     "var gopkg.in/yaml.v2.Default int",
+			expected: "Default",
+		},
+		{
+			item:     "const github.com/a-h/nonexistent.HTTPNotFound untyped int = 400",
+			expected: "HTTPNotFound",
+		},
+		{
+			item:     "struct Test { field Public string }",
+			expected: "Test",
+		},
+		{
+			item:     "struct Test {}",
+			expected: "Test",
+		},
+		{
+			item:     "github.com/a-h/nonexistent.Test",
+			expected: "Test",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := itemName(test.item); actual != test.expected {
+			t.Errorf("for %s; expected '%s', but got '%s'", test.item, test.expected, actual)
+		}
+	}
+}
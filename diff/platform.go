@@ -0,0 +1,78 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/a-h/ver/signature"
+)
+
+// platformChanges finds items which exist in both signatures, but are available on a
+// different set of the platforms that both signatures were calculated for.
+func platformChanges(current signature.Signature, next signature.Signature) []Change {
+	var changes []Change
+
+	currentElements := elements(current)
+
+	for i, e := range elements(next) {
+		currentItems := makeStringMap(currentElements[i].items)
+
+		for _, item := range e.items {
+			if !currentItems[item] {
+				continue
+			}
+
+			currentPlatforms := current.AvailableOn(item)
+			nextPlatforms := next.AvailableOn(item)
+
+			if currentPlatforms == nil || nextPlatforms == nil {
+				continue
+			}
+
+			// Only compare platforms which both signatures were calculated for.
+			removed := missingFrom(currentPlatforms, nextPlatforms, next.Platforms)
+			added := missingFrom(nextPlatforms, currentPlatforms, current.Platforms)
+
+			if len(removed) > 0 {
+				changes = append(changes, Change{
+					Kind:     PlatformRemoved,
+					Element:  e.name,
+					Name:     itemName(item),
+					Previous: item,
+					Current:  item,
+					Impact:   Breaking,
+					Reason:   "no longer available on " + strings.Join(removed, ", "),
+				})
+			}
+
+			if len(added) > 0 {
+				changes = append(changes, Change{
+					Kind:     PlatformAdded,
+					Element:  e.name,
+					Name:     itemName(item),
+					Previous: item,
+					Current:  item,
+					Impact:   Addition,
+					Reason:   "now available on " + strings.Join(added, ", "),
+				})
+			}
+		}
+	}
+
+	return changes
+}
+
+// missingFrom returns the platforms in a which are not in b, but are in analysed.
+func missingFrom(a []string, b []string, analysed []string) []string {
+	rv := []string{}
+
+	inB := makeStringMap(b)
+	inAnalysed := makeStringMap(analysed)
+
+	for _, p := range a {
+		if !inB[p] && inAnalysed[p] {
+			rv = append(rv, p)
+		}
+	}
+
+	return rv
+}
@@ -0,0 +1,54 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatItemsRemovedFromAPlatformAreReported(t *testing.T) {
+	current := signature.PackageSignatures{
+		"packageA": signature.Signature{
+			Functions: []string{"func a.A()", "func a.B()", "func a.C()"},
+			Platforms: []string{"linux/amd64", "windows/amd64"},
+			Availability: map[string][]string{
+				"func a.C()": []string{"linux/amd64"},
+			},
+		},
+	}
+
+	next := signature.PackageSignatures{
+		"packageA": signature.Signature{
+			Functions: []string{"func a.A()", "func a.B()", "func a.C()"},
+			Platforms: []string{"linux/amd64", "windows/amd64", "darwin/arm64"},
+			Availability: map[string][]string{
+				"func a.B()": []string{"linux/amd64", "darwin/arm64"},
+				"func a.C()": []string{"linux/amd64", "windows/amd64"},
+			},
+		},
+	}
+
+	actual := Calculate(current, next)
+
+	if len(actual.Packages) != 1 {
+		t.Fatalf("expected 1 package, but got %d", len(actual.Packages))
+	}
+
+	changes := actual.Packages[0].Changes
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, but got %v", changes)
+	}
+
+	if changes[0].Kind != PlatformRemoved || changes[0].Name != "B" || changes[0].Impact != Breaking {
+		t.Errorf("expected B to be removed from a platform, but got %v", changes[0])
+	}
+
+	if changes[0].Reason != "no longer available on windows/amd64" {
+		t.Errorf("unexpected reason: %s", changes[0].Reason)
+	}
+
+	if changes[1].Kind != PlatformAdded || changes[1].Name != "C" || changes[1].Impact != Addition {
+		t.Errorf("expected C to be added to a platform, but got %v", changes[1])
+	}
+}
@@ -0,0 +1,206 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/a-h/ver/signature"
+)
+
+// candidate is an item which was removed from, or added to, a package, and isn't paired with
+// an item of the same name.
+type candidate struct {
+	pkg     string
+	element string
+	item    string
+	used    bool
+}
+
+// detectRenames pairs items which were removed with items of the same shape which were added,
+// either to the same package with a different name, or to another package with the same name. Each pair is reported
+// as a single rename or move, instead of a removal and an addition. If a type alias with the
+// previous name was kept, the rename or move doesn't break existing code. The candidates which
+// are paired are marked as used.
+func detectRenames(d *SummaryDiff, next signature.PackageSignatures, removed []*candidate, added []*candidate) {
+	packages := packagesByName(d)
+
+	for _, r := range removed {
+		if r.element == "interfaces" || strings.HasPrefix(r.item, "method ") {
+			// Interfaces are rendered without their methods, and methods move with their type.
+			continue
+		}
+
+		var match *candidate
+		matches := 0
+
+		for _, a := range added {
+			if a.used || a.element != r.element || shape(a.item, a.pkg) != shape(r.item, r.pkg) {
+				continue
+			}
+
+			// Items which are renamed and moved at the same time are too hard to tell apart from unrelated changes.
+			if a.pkg != r.pkg && itemName(a.item) != itemName(r.item) {
+				continue
+			}
+
+			match = a
+			matches++
+		}
+
+		// Items with the same shape, such as empty structs, are common, so only unique matches are used.
+		if matches != 1 {
+			continue
+		}
+
+		match.used = true
+		r.used = true
+
+		previousName, currentName := itemName(r.item), itemName(match.item)
+		aliased := hasAlias(next[r.pkg], r.pkg, previousName, match.pkg+"."+currentName)
+
+		c := Change{
+			Kind:     Renamed,
+			Element:  r.element,
+			Name:     previousName,
+			Previous: r.item,
+			Current:  match.item,
+			Impact:   Breaking,
+			Reason:   "renamed from " + previousName + " to " + currentName,
+		}
+
+		if r.pkg != match.pkg {
+			c.Kind = Moved
+			c.Reason = "moved from " + r.pkg + " to " + match.pkg
+		}
+
+		if aliased {
+			c.Impact = Addition
+			c.Reason += ", and a type alias with the previous name was kept, so existing code still compiles"
+		} else {
+			c.Reason += ", without a type alias for the previous name"
+		}
+
+		packages[r.pkg].element(r.element).Removed--
+		packages[match.pkg].element(match.element).Added--
+		packages[r.pkg].Changes = append(packages[r.pkg].Changes, c)
+
+		if r.element == "structs" || r.element == "types" {
+			pairMethods(packages, removed, added, r.pkg, previousName, match.pkg, currentName)
+		}
+	}
+}
+
+// pairMethods removes the methods of a renamed or moved type from the count of removed and
+// added functions, since they're described by the change to the type.
+func pairMethods(packages map[string]*PackageDiff, removed []*candidate, added []*candidate,
+	previousPkg string, previousName string, currentPkg string, currentName string) {
+	for _, r := range removed {
+		if r.used || r.pkg != previousPkg || !isMethodOf(r.item, previousPkg, previousName) {
+			continue
+		}
+
+		expected := methodShape(r.item, previousPkg, previousName)
+
+		for _, a := range added {
+			if a.used || a.pkg != currentPkg || !isMethodOf(a.item, currentPkg, currentName) {
+				continue
+			}
+
+			if methodShape(a.item, currentPkg, currentName) != expected {
+				continue
+			}
+
+			r.used = true
+			a.used = true
+			packages[previousPkg].Functions.Removed--
+			packages[currentPkg].Functions.Added--
+			break
+		}
+	}
+}
+
+// unpaired returns the items in a which aren't in b, and don't have the same name as an item in b.
+func unpaired(a signature.PackageSignatures, b signature.PackageSignatures) []*candidate {
+	var rv []*candidate
+
+	packages := []string{}
+
+	for pkg := range a {
+		packages = append(packages, pkg)
+	}
+
+	sort.Strings(packages)
+
+	for _, pkg := range packages {
+		other := elements(b[pkg])
+
+		for i, e := range elements(a[pkg]) {
+			otherNames := names(other[i].items)
+
+			for _, item := range difference(e.items, other[i].items) {
+				if _, ok := otherNames[itemName(item)]; ok {
+					continue
+				}
+
+				rv = append(rv, &candidate{pkg: pkg, element: e.name, item: item})
+			}
+		}
+	}
+
+	return rv
+}
+
+// shape returns the item without its name or references to its own package, e.g.
+// "func a.Get(id a.ID) string" has the shape "func _(id ID) string".
+func shape(item string, pkg string) string {
+	prefix := ""
+
+	if i := strings.Index(item, " "); i >= 0 {
+		prefix, item = item[:i+1], item[i+1:]
+	}
+
+	return strings.Replace(prefix+"_"+item[len(identifier(item)):], pkg+".", "", -1)
+}
+
+// isMethodOf returns true if the item is a method of the named type, or a pointer to it.
+func isMethodOf(item string, pkg string, name string) bool {
+	return strings.HasPrefix(item, "method ("+pkg+"."+name+") ") || strings.HasPrefix(item, "method (*"+pkg+"."+name+") ")
+}
+
+// methodShape returns the method without its receiver's name or references to its own package,
+// e.g. "method (*a.Old) Get() a.ID" has the shape "method (*_) Get() ID".
+func methodShape(item string, pkg string, name string) string {
+	item = strings.Replace(item, "("+pkg+"."+name+")", "(_)", 1)
+	item = strings.Replace(item, "(*"+pkg+"."+name+")", "(*_)", 1)
+
+	return strings.Replace(item, pkg+".", "", -1)
+}
+
+// hasAlias returns true if the signature has a type alias with the name, which refers to the target.
+func hasAlias(sig signature.Signature, pkg string, name string, target string) bool {
+	for _, t := range sig.Types {
+		if t == "type "+pkg+"."+name+" = "+target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// element returns the diff of an element of the package, e.g. "functions".
+func (pd *PackageDiff) element(name string) *Diff {
+	switch name {
+	case "functions":
+		return &pd.Functions
+	case "fields":
+		return &pd.Fields
+	case "constants":
+		return &pd.Constants
+	case "structs":
+		return &pd.Structs
+	case "interfaces":
+		return &pd.Interfaces
+	}
+
+	return &pd.Types
+}
@@ -0,0 +1,85 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatRenamesWithAnAliasAreNotBreaking(t *testing.T) {
+	current := signature.PackageSignatures{"a": signature.Signature{
+		Structs:   []string{"struct Client { field URL string }"},
+		Functions: []string{"method (*a.Client) Get(id a.ID) string"},
+	}}
+	next := signature.PackageSignatures{"a": signature.Signature{
+		Structs:   []string{"struct HTTPClient { field URL string }"},
+		Functions: []string{"method (*a.HTTPClient) Get(id a.ID) string"},
+		Types:     []string{"type a.Client = a.HTTPClient"},
+	}}
+
+	actual := Calculate(current, next).Packages[0]
+
+	if actual.Structs != (Diff{}) || actual.Functions != (Diff{}) {
+		t.Errorf("expected the struct and its methods to be paired, but got structs %v and functions %v", actual.Structs, actual.Functions)
+	}
+
+	if actual.Types != (Diff{Added: 1}) {
+		t.Errorf("expected the alias to be added, but got %v", actual.Types)
+	}
+
+	if len(actual.Changes) != 1 {
+		t.Fatalf("expected 1 change, but got %v", actual.Changes)
+	}
+
+	c := actual.Changes[0]
+
+	if c.Kind != Renamed || c.Name != "Client" || c.Impact != Addition {
+		t.Errorf("expected a compatible rename, but got %v", c)
+	}
+
+	if expected := "renamed from Client to HTTPClient, and a type alias with the previous name was kept, so existing code still compiles"; c.Reason != expected {
+		t.Errorf("expected reason %q, but got %q", expected, c.Reason)
+	}
+}
+
+func TestThatMovesBetweenPackagesAreDetected(t *testing.T) {
+	current := signature.PackageSignatures{
+		"m/a": signature.Signature{Functions: []string{"func m/a.Parse(s string) (m/a.Version, error)", "func m/a.Other()"}},
+		"m/b": signature.Signature{},
+	}
+	next := signature.PackageSignatures{
+		"m/a": signature.Signature{Functions: []string{"func m/a.Other()"}},
+		"m/b": signature.Signature{Functions: []string{"func m/b.Parse(s string) (m/b.Version, error)"}},
+	}
+
+	d := Calculate(current, next)
+
+	for _, pd := range d.Packages {
+		if pd.Functions != (Diff{}) {
+			t.Errorf("expected the functions of %s to be paired, but got %v", pd.PackageName, pd.Functions)
+		}
+
+		if pd.PackageName != "m/a" {
+			continue
+		}
+
+		if len(pd.Changes) != 1 || pd.Changes[0].Kind != Moved || pd.Changes[0].Impact != Breaking {
+			t.Errorf("expected a breaking move, but got %v", pd.Changes)
+		}
+	}
+}
+
+func TestThatAmbiguousRenamesAreNotPaired(t *testing.T) {
+	current := signature.PackageSignatures{"a": signature.Signature{
+		Structs: []string{"struct A {}"},
+	}}
+	next := signature.PackageSignatures{"a": signature.Signature{
+		Structs: []string{"struct B {}", "struct C {}"},
+	}}
+
+	actual := Calculate(current, next).Packages[0]
+
+	if actual.Structs != (Diff{Added: 2, Removed: 1}) || len(actual.Changes) != 0 {
+		t.Errorf("expected the structs not to be paired, but got %v and %v", actual.Structs, actual.Changes)
+	}
+}
@@ -0,0 +1,312 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/a-h/ver/signature"
+)
+
+// diffStructs compares the structs of two signatures. Structs which are rendered the same
+// in both signatures can still change, e.g. by becoming incomparable when an unexported
+// field is added, so their details are compared too.
+func diffStructs(current signature.Signature, next signature.Signature) (Diff, []Change) {
+	d, changes := diffItems("structs", current.Structs, next.Structs, structClassifier(current, next))
+
+	currentItems := makeStringMap(current.Structs)
+
+	for _, item := range next.Structs {
+		if !currentItems[item] {
+			continue
+		}
+
+		name := itemName(item)
+		p, pok := current.StructDetails[name]
+		n, nok := next.StructDetails[name]
+
+		if !pok || !nok {
+			continue
+		}
+
+		base := Change{
+			Element:  "structs",
+			Name:     name,
+			Previous: item,
+			Current:  item,
+		}
+
+		if c := compareStructs(base, p, n); len(c) > 0 {
+			d.Changed++
+			changes = append(changes, c...)
+		}
+	}
+
+	return d, changes
+}
+
+// structClassifier describes the changes to structs, using the details of the struct in each signature.
+func structClassifier(current signature.Signature, next signature.Signature) classifier {
+	return func(element string, previous string, curr string) []Change {
+		base := Change{
+			Element:  element,
+			Name:     itemName(curr),
+			Previous: previous,
+			Current:  curr,
+		}
+
+		p, pok := current.StructDetails[base.Name]
+		n, nok := next.StructDetails[base.Name]
+
+		if pok && nok {
+			if changes := compareStructs(base, p, n); len(changes) > 0 {
+				return changes
+			}
+		}
+
+		// Signatures saved by older versions don't have the details of each struct.
+		base.Kind = StructChanged
+		base.Impact = Breaking
+		base.Reason = "fields changed"
+
+		return []Change{base}
+	}
+}
+
+// compareStructs lists the changes between two versions of a struct.
+func compareStructs(base Change, previous signature.Struct, current signature.Struct) []Change {
+	var changes []Change
+
+	removed := []string{}
+	tags := []Change{}
+
+	for _, pf := range previous.Fields {
+		cf, index := current.Field(pf.Name)
+
+		if index < 0 {
+			removed = append(removed, "field "+pf.Name+" removed")
+			continue
+		}
+
+		if cf.Type != pf.Type {
+			removed = append(removed, "field "+pf.Name+" changed from "+pf.Type+" to "+cf.Type)
+			continue
+		}
+
+		if cf.Tag != pf.Tag {
+			c := base
+			c.Kind = TagChanged
+			c.Impact = Breaking
+			c.Reason = "tag of field " + pf.Name + " changed from `" + pf.Tag + "` to `" + cf.Tag + "`"
+			tags = append(tags, c)
+		}
+	}
+
+	if len(removed) > 0 {
+		c := base
+		c.Kind = FieldsChanged
+		c.Impact = Breaking
+		c.Reason = strings.Join(removed, "; ")
+		changes = append(changes, c)
+	}
+
+	added := []string{}
+
+	for _, cf := range current.Fields {
+		if _, index := previous.Field(cf.Name); index < 0 {
+			added = append(added, cf.Name)
+		}
+	}
+
+	if len(added) > 0 {
+		c := base
+		c.Kind = FieldsAdded
+		c.Impact = Addition
+		c.Reason = "fields added: " + strings.Join(added, ", ")
+		changes = append(changes, c)
+	}
+
+	changes = append(changes, tags...)
+	changes = append(changes, compareStructProperties(base, previous, current)...)
+	changes = append(changes, compareLayouts(base, previous, current)...)
+	changes = append(changes, comparePromoted(base, previous, current)...)
+
+	previousOrder, currentOrder := commonFieldOrder(previous, current)
+
+	if strings.Join(previousOrder, ",") != strings.Join(currentOrder, ",") {
+		c := base
+		c.Kind = FieldsReordered
+		c.Impact = Breaking
+		c.Reason = "fields reordered from " + strings.Join(previousOrder, ", ") + " to " + strings.Join(currentOrder, ", ") +
+			", which breaks unkeyed struct literals"
+		changes = append(changes, c)
+	}
+
+	return changes
+}
+
+// compareStructProperties lists changes to the comparability of a struct, and to whether it has unexported fields.
+func compareStructProperties(base Change, previous signature.Struct, current signature.Struct) []Change {
+	var changes []Change
+
+	if previous.Comparable != current.Comparable {
+		c := base
+
+		if current.Comparable {
+			c.Kind = ComparabilityGained
+			c.Impact = Addition
+			c.Reason = "struct is now comparable"
+		} else {
+			c.Kind = ComparabilityLost
+			c.Impact = Breaking
+			c.Reason = "struct is no longer comparable, so it can't be compared with == or used as a map key"
+		}
+
+		changes = append(changes, c)
+	}
+
+	if previous.Unexported != current.Unexported {
+		c := base
+
+		if current.Unexported {
+			c.Kind = UnexportedFieldsAdded
+			c.Impact = Breaking
+			c.Reason = "struct now has unexported fields, so it can't be created with an unkeyed struct literal"
+		} else {
+			c.Kind = UnexportedFieldsRemoved
+			c.Impact = Compatible
+			c.Reason = "struct no longer has unexported fields"
+		}
+
+		changes = append(changes, c)
+	}
+
+	return changes
+}
+
+// compareLayouts lists the changes to the memory layout of a struct on each architecture
+// that both versions of the struct have a layout for.
+func compareLayouts(base Change, previous signature.Struct, current signature.Struct) []Change {
+	var changes []Change
+
+	architectures := []string{}
+
+	for arch := range current.Layouts {
+		if _, ok := previous.Layouts[arch]; ok {
+			architectures = append(architectures, arch)
+		}
+	}
+
+	sort.Strings(architectures)
+
+	for _, arch := range architectures {
+		p, c := previous.Layouts[arch], current.Layouts[arch]
+		reasons := []string{}
+
+		if p.Size != c.Size {
+			reasons = append(reasons, fmt.Sprintf("size changed from %d to %d", p.Size, c.Size))
+		}
+
+		if p.Align != c.Align {
+			reasons = append(reasons, fmt.Sprintf("alignment changed from %d to %d", p.Align, c.Align))
+		}
+
+		if fmt.Sprint(p.Offsets) != fmt.Sprint(c.Offsets) {
+			reasons = append(reasons, fmt.Sprintf("field offsets changed from %v to %v", p.Offsets, c.Offsets))
+		}
+
+		if len(reasons) == 0 {
+			continue
+		}
+
+		change := base
+		change.Kind = LayoutChanged
+		change.Impact = Compatible
+		change.Reason = arch + ": " + strings.Join(reasons, "; ")
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// comparePromoted lists the changes to the methods and fields promoted to a struct from its embedded
+// types. The embedded field and the package of the embedded type are included in each reason, since the
+// change may be caused by a dependency, rather than the package itself.
+func comparePromoted(base Change, previous signature.Struct, current signature.Struct) []Change {
+	var changes []Change
+
+	find := func(promoted []signature.Promoted, p signature.Promoted) (signature.Promoted, bool) {
+		for _, candidate := range promoted {
+			if candidate.Kind == p.Kind && candidate.Name == p.Name {
+				return candidate, true
+			}
+		}
+		return signature.Promoted{}, false
+	}
+
+	for _, p := range previous.Promoted {
+		c := base
+		c.Name = base.Name + "." + p.Name
+
+		cp, ok := find(current.Promoted, p)
+
+		switch {
+		case !ok:
+			c.Kind = PromotedRemoved
+			c.Impact = Breaking
+			c.Reason = p.Kind + " " + p.Name + " is no longer promoted from " + describePromoted(p)
+		case cp.Type != p.Type:
+			c.Kind = PromotedChanged
+			c.Impact = Breaking
+			c.Reason = p.Kind + " " + p.Name + " changed from " + p.Type + " promoted from " + describePromoted(p) +
+				" to " + cp.Type + " promoted from " + describePromoted(cp)
+		case cp.Via != p.Via || cp.Origin != p.Origin:
+			// Uses of the method or field still compile. A change to the type of the embedded field
+			// is reported as a change to the fields of the struct.
+			c.Kind = PromotedChanged
+			c.Impact = Compatible
+			c.Reason = p.Kind + " " + p.Name + " is now promoted from " + describePromoted(cp) + " instead of " + describePromoted(p)
+		default:
+			continue
+		}
+
+		changes = append(changes, c)
+	}
+
+	for _, cp := range current.Promoted {
+		if _, ok := find(previous.Promoted, cp); ok {
+			continue
+		}
+
+		c := base
+		c.Name = base.Name + "." + cp.Name
+		c.Kind = PromotedAdded
+		c.Impact = Addition
+		c.Reason = cp.Kind + " " + cp.Name + " is now promoted from " + describePromoted(cp)
+		changes = append(changes, c)
+	}
+
+	return changes
+}
+
+// describePromoted describes where a method or field is promoted from, e.g. "Reader (io)".
+func describePromoted(p signature.Promoted) string {
+	return p.Via + " (" + p.Origin + ")"
+}
+
+// commonFieldOrder returns the names of the fields in both structs, in the order of each struct.
+func commonFieldOrder(a signature.Struct, b signature.Struct) (aOrder []string, bOrder []string) {
+	for _, f := range a.Fields {
+		if _, index := b.Field(f.Name); index >= 0 {
+			aOrder = append(aOrder, f.Name)
+		}
+	}
+
+	for _, f := range b.Fields {
+		if _, index := a.Field(f.Name); index >= 0 {
+			bOrder = append(bOrder, f.Name)
+		}
+	}
+
+	return aOrder, bOrder
+}
@@ -0,0 +1,277 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatStructChangesAreClassified(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  signature.Struct
+		next     signature.Struct
+		expected []Change
+	}{
+		{
+			name:     "Tag changed",
+			current:  signature.Struct{Fields: []signature.Field{{Name: "A", Type: "string", Tag: `json:"a"`}}},
+			next:     signature.Struct{Fields: []signature.Field{{Name: "A", Type: "string", Tag: `json:"b"`}}},
+			expected: []Change{{Kind: TagChanged, Impact: Breaking, Reason: "tag of field A changed from `json:\"a\"` to `json:\"b\"`"}},
+		},
+		{
+			name:     "Fields reordered",
+			current:  signature.Struct{Fields: []signature.Field{{Name: "A", Type: "string"}, {Name: "B", Type: "int"}}},
+			next:     signature.Struct{Fields: []signature.Field{{Name: "B", Type: "int"}, {Name: "A", Type: "string"}}},
+			expected: []Change{{Kind: FieldsReordered, Impact: Breaking, Reason: "fields reordered from A, B to B, A, which breaks unkeyed struct literals"}},
+		},
+		{
+			name:     "Field added",
+			current:  signature.Struct{Fields: []signature.Field{{Name: "A", Type: "string"}}},
+			next:     signature.Struct{Fields: []signature.Field{{Name: "A", Type: "string"}, {Name: "B", Type: "int"}}},
+			expected: []Change{{Kind: FieldsAdded, Impact: Addition, Reason: "fields added: B"}},
+		},
+		{
+			name:    "Field removed and type changed",
+			current: signature.Struct{Fields: []signature.Field{{Name: "A", Type: "string"}, {Name: "B", Type: "int"}}},
+			next:    signature.Struct{Fields: []signature.Field{{Name: "A", Type: "int"}}},
+			expected: []Change{
+				{Kind: FieldsChanged, Impact: Breaking, Reason: "field A changed from string to int; field B removed"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		current := signature.PackageSignatures{"a": signature.Signature{
+			Structs:       []string{"struct Test { previous }"},
+			StructDetails: map[string]signature.Struct{"Test": tt.current},
+		}}
+		next := signature.PackageSignatures{"a": signature.Signature{
+			Structs:       []string{"struct Test { next }"},
+			StructDetails: map[string]signature.Struct{"Test": tt.next},
+		}}
+
+		actual := Calculate(current, next).Packages[0]
+
+		if actual.Structs != (Diff{Changed: 1}) {
+			t.Errorf("%q. Expected the struct to be changed, but got %v", tt.name, actual.Structs)
+		}
+
+		if len(actual.Changes) != len(tt.expected) {
+			t.Errorf("%q. Expected %v but got %v", tt.name, tt.expected, actual.Changes)
+			continue
+		}
+
+		for i, c := range actual.Changes {
+			e := tt.expected[i]
+			if c.Kind != e.Kind || c.Impact != e.Impact || c.Reason != e.Reason || c.Name != "Test" {
+				t.Errorf("%q. Expected %v but got %v", tt.name, e, c)
+			}
+		}
+	}
+}
+
+func TestThatStructsWithoutDetailsAreChanged(t *testing.T) {
+	current := signature.PackageSignatures{"a": signature.Signature{Structs: []string{"struct Test { field A string }"}}}
+	next := signature.PackageSignatures{"a": signature.Signature{Structs: []string{"struct Test { field A int }"}}}
+
+	changes := Calculate(current, next).Packages[0].Changes
+
+	if len(changes) != 1 || changes[0].Kind != StructChanged || changes[0].Impact != Breaking {
+		t.Errorf("expected a breaking struct change, but got %v", changes)
+	}
+}
+
+func TestThatComparabilityAndUnexportedFieldChangesAreReported(t *testing.T) {
+	fields := []signature.Field{{Name: "A", Type: "string"}}
+
+	current := signature.PackageSignatures{"a": signature.Signature{
+		Structs:       []string{"struct Test { field A string }"},
+		StructDetails: map[string]signature.Struct{"Test": {Fields: fields, Comparable: true}},
+	}}
+	next := signature.PackageSignatures{"a": signature.Signature{
+		Structs:       []string{"struct Test { field A string }"},
+		StructDetails: map[string]signature.Struct{"Test": {Fields: fields, Comparable: false, Unexported: true}},
+	}}
+
+	actual := Calculate(current, next).Packages[0]
+
+	if actual.Structs != (Diff{Changed: 1}) {
+		t.Errorf("expected the struct to be changed, but got %v", actual.Structs)
+	}
+
+	if len(actual.Changes) != 2 {
+		t.Fatalf("expected 2 changes, but got %v", actual.Changes)
+	}
+
+	if actual.Changes[0].Kind != ComparabilityLost || actual.Changes[0].Impact != Breaking {
+		t.Errorf("expected a breaking loss of comparability, but got %v", actual.Changes[0])
+	}
+
+	if actual.Changes[1].Kind != UnexportedFieldsAdded || actual.Changes[1].Impact != Breaking {
+		t.Errorf("expected unexported fields to be added, but got %v", actual.Changes[1])
+	}
+}
+
+func TestThatLayoutChangesAreReported(t *testing.T) {
+	current := signature.PackageSignatures{"a": signature.Signature{
+		Structs: []string{"struct Test {}"},
+		StructDetails: map[string]signature.Struct{"Test": {
+			Layouts: map[string]signature.Layout{
+				"amd64": {Size: 16, Align: 8, Offsets: []int64{0, 8}},
+				"386":   {Size: 12, Align: 4, Offsets: []int64{0, 4}},
+			},
+		}},
+	}}
+	next := signature.PackageSignatures{"a": signature.Signature{
+		Structs: []string{"struct Test {}"},
+		StructDetails: map[string]signature.Struct{"Test": {
+			Layouts: map[string]signature.Layout{
+				"amd64": {Size: 24, Align: 8, Offsets: []int64{0, 16}},
+				"386":   {Size: 12, Align: 4, Offsets: []int64{0, 4}},
+				"arm64": {Size: 24, Align: 8, Offsets: []int64{0, 16}},
+			},
+		}},
+	}}
+
+	changes := Calculate(current, next).Packages[0].Changes
+
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, but got %v", changes)
+	}
+
+	if changes[0].Kind != LayoutChanged || changes[0].Impact != Compatible {
+		t.Errorf("expected a compatible layout change, but got %v", changes[0])
+	}
+
+	if expected := "amd64: size changed from 16 to 24; field offsets changed from [0 8] to [0 16]"; changes[0].Reason != expected {
+		t.Errorf("expected reason %q, but got %q", expected, changes[0].Reason)
+	}
+}
+
+func TestThatPromotedChangesAreAttributedToTheEmbeddedType(t *testing.T) {
+	current := signature.PackageSignatures{"a": signature.Signature{
+		Structs: []string{"struct Test { field Reader io.Reader }"},
+		StructDetails: map[string]signature.Struct{"Test": {
+			Promoted: []signature.Promoted{
+				{Name: "Read", Kind: "method", Type: "func(p []byte) (n int, err error)", Via: "Reader", Origin: "io"},
+				{Name: "Close", Kind: "method", Type: "func() error", Via: "Reader", Origin: "github.com/x/y"},
+			},
+		}},
+	}}
+	next := signature.PackageSignatures{"a": signature.Signature{
+		Structs: []string{"struct Test { field Reader io.Reader }"},
+		StructDetails: map[string]signature.Struct{"Test": {
+			Promoted: []signature.Promoted{
+				{Name: "Read", Kind: "method", Type: "func(p []byte) (n int, err error)", Via: "Reader", Origin: "io"},
+				{Name: "ID", Kind: "field", Type: "string", Via: "Reader", Origin: "github.com/x/y"},
+			},
+		}},
+	}}
+
+	changes := Calculate(current, next).Packages[0].Changes
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, but got %v", changes)
+	}
+
+	if changes[0].Kind != PromotedRemoved || changes[0].Name != "Test.Close" || changes[0].Impact != Breaking ||
+		changes[0].Reason != "method Close is no longer promoted from Reader (github.com/x/y)" {
+		t.Errorf("expected Close to be removed, but got %v", changes[0])
+	}
+
+	if changes[1].Kind != PromotedAdded || changes[1].Name != "Test.ID" || changes[1].Impact != Addition {
+		t.Errorf("expected ID to be added, but got %v", changes[1])
+	}
+}
+
+func TestThatPromotedMethodsAreNotAlsoCountedAsFunctionChanges(t *testing.T) {
+	current := signature.PackageSignatures{"a": signature.Signature{
+		Functions: []string{"method (*a.Test) Close()", "method (*a.Test) Read()", "method (a.Test) Close()", "method (a.Test) Read()"},
+		Structs:   []string{"struct Test { field Reader y.Reader }"},
+		StructDetails: map[string]signature.Struct{"Test": {
+			Promoted: []signature.Promoted{
+				{Name: "Close", Kind: "method", Type: "func()", Via: "Reader", Origin: "github.com/x/y"},
+				{Name: "Read", Kind: "method", Type: "func()", Via: "Reader", Origin: "github.com/x/y"},
+			},
+		}},
+	}}
+	next := signature.PackageSignatures{"a": signature.Signature{
+		Functions: []string{"method (*a.Test) Open()", "method (*a.Test) Read() error", "method (a.Test) Open()", "method (a.Test) Read() error"},
+		Structs:   []string{"struct Test { field Reader y.Reader }"},
+		StructDetails: map[string]signature.Struct{"Test": {
+			Promoted: []signature.Promoted{
+				{Name: "Open", Kind: "method", Type: "func()", Via: "Reader", Origin: "github.com/x/y"},
+				{Name: "Read", Kind: "method", Type: "func() error", Via: "Reader", Origin: "github.com/x/y"},
+			},
+		}},
+	}}
+
+	pd := Calculate(current, next).Packages[0]
+
+	if pd.Functions != (Diff{}) {
+		t.Errorf("expected the promoted methods not to be counted as functions, but got %v", pd.Functions)
+	}
+
+	if len(pd.Items) != 0 {
+		t.Errorf("expected no items to be listed as added or removed, but got %v", pd.Items)
+	}
+
+	kinds := []Kind{}
+
+	for _, c := range pd.Changes {
+		kinds = append(kinds, c.Kind)
+	}
+
+	if !reflect.DeepEqual(kinds, []Kind{PromotedRemoved, PromotedAdded, PromotedChanged}) {
+		t.Errorf("expected only the promoted changes, but got %v", pd.Changes)
+	}
+}
+
+func TestThatPromotedMembersFromADifferentOriginWithTheSameTypeAreCompatible(t *testing.T) {
+	promoted := func(origin string, typ string) signature.PackageSignatures {
+		return signature.PackageSignatures{"a": signature.Signature{
+			Structs: []string{"struct Test { field Reader *" + origin + ".Reader }"},
+			StructDetails: map[string]signature.Struct{"Test": {
+				Promoted: []signature.Promoted{
+					{Name: "Read", Kind: "method", Type: typ, Via: "Reader", Origin: origin},
+				},
+			}},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		current  signature.PackageSignatures
+		next     signature.PackageSignatures
+		expected Impact
+	}{
+		{
+			name:     "Same type from a different origin",
+			current:  promoted("bytes", "func(b []byte) (n int, err error)"),
+			next:     promoted("strings", "func(b []byte) (n int, err error)"),
+			expected: Compatible,
+		},
+		{
+			name:     "Different type from a different origin",
+			current:  promoted("bytes", "func(b []byte) (n int, err error)"),
+			next:     promoted("strings", "func(b []byte) error"),
+			expected: Breaking,
+		},
+	}
+
+	for _, tt := range tests {
+		var actual []Change
+
+		for _, c := range Calculate(tt.current, tt.next).Packages[0].Changes {
+			if c.Kind == PromotedChanged {
+				actual = append(actual, c)
+			}
+		}
+
+		if len(actual) != 1 || actual[0].Name != "Test.Read" || actual[0].Impact != tt.expected {
+			t.Errorf("%q. Expected Read to be changed with impact %s, but got %v", tt.name, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package diff
+
+import "strings"
+
+// classifyType describes the change to a named type, e.g. from "type a.Mode int" to "type a.Mode string".
+func classifyType(element string, previous string, current string) []Change {
+	c := Change{
+		Element:  element,
+		Name:     itemName(current),
+		Previous: previous,
+		Current:  current,
+		Impact:   Breaking,
+	}
+
+	previousAlias, previousType := parseType(previous)
+	currentAlias, currentType := parseType(current)
+
+	switch {
+	case previousAlias && currentAlias:
+		c.Kind = AliasTargetChanged
+		c.Reason = "alias now refers to " + currentType + " instead of " + previousType
+	case previousAlias:
+		c.Kind = AliasToDefinition
+		c.Reason = "alias of " + previousType + " is now a distinct type, so values are no longer interchangeable"
+	case currentAlias:
+		c.Kind = DefinitionToAlias
+		c.Reason = "type is now an alias of " + currentType + ", so it no longer has its own methods or identity"
+	default:
+		c.Kind = UnderlyingTypeChanged
+		c.Reason = "underlying type changed from " + previousType + " to " + currentType
+	}
+
+	return []Change{c}
+}
+
+// parseType splits a type rendered by the signature package into whether it's an alias,
+// and the type it's defined as, e.g. "type a.Mode int" is not an alias, and is defined as "int".
+func parseType(item string) (alias bool, typ string) {
+	rest := strings.TrimPrefix(item, "type ")
+	rest = rest[len(identifier(rest)):]
+
+	if strings.HasPrefix(rest, " = ") {
+		return true, strings.TrimPrefix(rest, " = ")
+	}
+
+	return false, strings.TrimPrefix(rest, " ")
+}
@@ -0,0 +1,74 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatTypeChangesAreClassified(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  []string
+		next     []string
+		expected Diff
+		kinds    []Kind
+	}{
+		{
+			name:     "Underlying type changed",
+			current:  []string{"type a.Mode int"},
+			next:     []string{"type a.Mode string"},
+			expected: Diff{Changed: 1},
+			kinds:    []Kind{UnderlyingTypeChanged},
+		},
+		{
+			name:     "Alias target changed",
+			current:  []string{"type a.A = a.B"},
+			next:     []string{"type a.A = a.C"},
+			expected: Diff{Changed: 1},
+			kinds:    []Kind{AliasTargetChanged},
+		},
+		{
+			name:     "Alias replaced by a definition",
+			current:  []string{"type a.A = a.B"},
+			next:     []string{"type a.A a.B"},
+			expected: Diff{Changed: 1},
+			kinds:    []Kind{AliasToDefinition},
+		},
+		{
+			name:     "Definition replaced by an alias",
+			current:  []string{"type a.IDs []string"},
+			next:     []string{"type a.IDs = []string"},
+			expected: Diff{Changed: 1},
+			kinds:    []Kind{DefinitionToAlias},
+		},
+		{
+			name:     "Types added and removed",
+			current:  []string{"type a.Mode int", "type a.Handler func(w io.Writer)"},
+			next:     []string{"type a.Mode int", "type a.IDs []string"},
+			expected: Diff{Added: 1, Removed: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		current := signature.PackageSignatures{"a": signature.Signature{Types: tt.current}}
+		next := signature.PackageSignatures{"a": signature.Signature{Types: tt.next}}
+
+		actual := Calculate(current, next).Packages[0]
+
+		if actual.Types != tt.expected {
+			t.Errorf("%q. Expected %v but got %v", tt.name, tt.expected, actual.Types)
+		}
+
+		if len(actual.Changes) != len(tt.kinds) {
+			t.Errorf("%q. Expected %d changes but got %v", tt.name, len(tt.kinds), actual.Changes)
+			continue
+		}
+
+		for i, c := range actual.Changes {
+			if c.Kind != tt.kinds[i] || c.Impact != Breaking || c.Element != "types" {
+				t.Errorf("%q. Expected a breaking %s change to types, but got %v", tt.name, tt.kinds[i], c)
+			}
+		}
+	}
+}
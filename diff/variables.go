@@ -0,0 +1,159 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/a-h/ver/signature"
+)
+
+// classifyVariable describes the change to an exported variable, e.g. from "var a.Timeout int"
+// to "var a.Timeout time.Duration".
+func classifyVariable(element string, previous string, current string) []Change {
+	c := Change{
+		Element:  element,
+		Name:     itemName(current),
+		Previous: previous,
+		Current:  current,
+		Impact:   Breaking,
+	}
+
+	previousType, currentType := variableType(previous), variableType(current)
+
+	c.Kind = VariableTypeChanged
+	c.Reason = "type changed from " + previousType + " to " + currentType
+
+	if isSentinelError(previous) {
+		c.Kind = SentinelErrorChanged
+		c.Reason = "sentinel error " + c.Reason + ", so it can no longer be compared with errors.Is or =="
+	}
+
+	return []Change{c}
+}
+
+// variableChanges finds exported variables which have become constants, constants which have become
+// variables, sentinel errors which have been removed, and variables whose initializer has changed.
+func variableChanges(current signature.Signature, next signature.Signature) []Change {
+	var changes []Change
+
+	nextVariables := names(next.Fields)
+	nextConstants := names(next.Constants)
+
+	for _, item := range difference(current.Fields, next.Fields) {
+		name := itemName(item)
+
+		if _, ok := nextVariables[name]; ok {
+			// The variable has changed type, rather than being removed.
+			continue
+		}
+
+		c := Change{
+			Element:  "fields",
+			Name:     name,
+			Previous: item,
+			Impact:   Breaking,
+		}
+
+		if constant, ok := nextConstants[name]; ok {
+			c.Kind = VarToConst
+			c.Current = constant
+			c.Reason = "variable is now a constant, so it can no longer be assigned to, or have its address taken"
+		} else if isSentinelError(item) {
+			c.Kind = SentinelErrorRemoved
+			c.Reason = "sentinel error removed, so callers can no longer check for it"
+		} else {
+			continue
+		}
+
+		changes = append(changes, c)
+	}
+
+	for _, item := range current.Constants {
+		name := itemName(item)
+
+		if _, ok := nextConstants[name]; ok {
+			continue
+		}
+
+		if variable, ok := nextVariables[name]; ok {
+			changes = append(changes, Change{
+				Kind:     ConstToVar,
+				Element:  "constants",
+				Name:     name,
+				Previous: item,
+				Current:  variable,
+				Impact:   Breaking,
+				Reason:   "constant is now a variable, so it can no longer be used in constant expressions",
+			})
+		}
+	}
+
+	changes = append(changes, initializerChanges(current, next)...)
+
+	return changes
+}
+
+// initializerChanges finds exported variables which are initialized with a different expression.
+func initializerChanges(current signature.Signature, next signature.Signature) []Change {
+	var changes []Change
+
+	variables := []string{}
+
+	for name := range next.Initializers {
+		if _, ok := current.Initializers[name]; ok {
+			variables = append(variables, name)
+		}
+	}
+
+	sort.Strings(variables)
+
+	for _, name := range variables {
+		previous, now := current.Initializers[name], next.Initializers[name]
+
+		if previous == now {
+			continue
+		}
+
+		changes = append(changes, Change{
+			Kind:     InitializerChanged,
+			Element:  "fields",
+			Name:     name,
+			Previous: previous,
+			Current:  now,
+			Impact:   Compatible,
+			Reason:   "initialized with " + now + " instead of " + previous,
+		})
+	}
+
+	return changes
+}
+
+// names maps the name of each item to the item.
+func names(items []string) map[string]string {
+	rv := make(map[string]string, len(items))
+
+	for _, item := range items {
+		rv[itemName(item)] = item
+	}
+
+	return rv
+}
+
+// variableType returns the type of a variable, e.g. "error" from "var a.ErrNotFound error".
+func variableType(item string) string {
+	rest := strings.TrimPrefix(item, "var ")
+	return strings.TrimPrefix(rest[len(identifier(rest)):], " ")
+}
+
+// isSentinelError returns true if the variable is an error which callers are expected to compare
+// against, e.g. "var io.EOF error" or "var a.ErrNotFound *a.NotFoundError".
+func isSentinelError(item string) bool {
+	if variableType(item) == "error" {
+		return true
+	}
+
+	name := itemName(item)
+
+	return name == "Err" || strings.HasPrefix(name, "Err") && unicode.IsUpper(rune(name[3]))
+}
@@ -0,0 +1,144 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatVariableChangesAreClassified(t *testing.T) {
+	tests := []struct {
+		name    string
+		current signature.Signature
+		next    signature.Signature
+		kinds   []Kind
+		impacts []Impact
+	}{
+		{
+			name:    "Sentinel error removed",
+			current: signature.Signature{Fields: []string{"var a.ErrNotFound error", "var a.Errors []string"}},
+			next:    signature.Signature{},
+			kinds:   []Kind{SentinelErrorRemoved},
+			impacts: []Impact{Breaking},
+		},
+		{
+			name:    "Sentinel error retyped",
+			current: signature.Signature{Fields: []string{"var a.ErrNotFound error"}},
+			next:    signature.Signature{Fields: []string{"var a.ErrNotFound *a.NotFoundError"}},
+			kinds:   []Kind{SentinelErrorChanged},
+			impacts: []Impact{Breaking},
+		},
+		{
+			name:    "Variable retyped",
+			current: signature.Signature{Fields: []string{"var a.Timeout int"}},
+			next:    signature.Signature{Fields: []string{"var a.Timeout time.Duration"}},
+			kinds:   []Kind{VariableTypeChanged},
+			impacts: []Impact{Breaking},
+		},
+		{
+			name:    "Variable to constant",
+			current: signature.Signature{Fields: []string{"var a.Timeout int"}},
+			next:    signature.Signature{Constants: []string{"const a.Timeout int = 10"}},
+			kinds:   []Kind{VarToConst},
+			impacts: []Impact{Breaking},
+		},
+		{
+			name:    "Constant to variable",
+			current: signature.Signature{Constants: []string{"const a.Timeout int = 10"}},
+			next:    signature.Signature{Fields: []string{"var a.Timeout int"}},
+			kinds:   []Kind{ConstToVar},
+			impacts: []Impact{Breaking},
+		},
+		{
+			name: "Initializer changed",
+			current: signature.Signature{
+				Fields:       []string{"var a.Timeout int"},
+				Initializers: map[string]string{"Timeout": "10"},
+			},
+			next: signature.Signature{
+				Fields:       []string{"var a.Timeout int"},
+				Initializers: map[string]string{"Timeout": "20"},
+			},
+			kinds:   []Kind{InitializerChanged},
+			impacts: []Impact{Compatible},
+		},
+	}
+
+	for _, tt := range tests {
+		current := signature.PackageSignatures{"a": tt.current}
+		next := signature.PackageSignatures{"a": tt.next}
+
+		changes := Calculate(current, next).Packages[0].Changes
+
+		if len(changes) != len(tt.kinds) {
+			t.Errorf("%q. Expected %d changes but got %v", tt.name, len(tt.kinds), changes)
+			continue
+		}
+
+		for i, c := range changes {
+			if c.Kind != tt.kinds[i] || c.Impact != tt.impacts[i] {
+				t.Errorf("%q. Expected a %s %s change, but got %v", tt.name, tt.impacts[i], tt.kinds[i], c)
+			}
+		}
+	}
+}
+
+func TestThatVariablesReplacedByConstantsAreNotAlsoCountedAsAddedOrRemoved(t *testing.T) {
+	current := signature.PackageSignatures{"a": signature.Signature{
+		Fields:    []string{"var a.Timeout int"},
+		Constants: []string{"const a.Max int = 10"},
+	}}
+	next := signature.PackageSignatures{"a": signature.Signature{
+		Fields:    []string{"var a.Max int"},
+		Constants: []string{"const a.Timeout int = 10"},
+	}}
+
+	pd := Calculate(current, next).Packages[0]
+
+	if pd.Fields != (Diff{}) || pd.Constants != (Diff{}) {
+		t.Errorf("expected the variables and constants not to be counted, but got %v and %v", pd.Fields, pd.Constants)
+	}
+
+	if len(pd.Items) != 0 {
+		t.Errorf("expected no items to be listed as added or removed, but got %v", pd.Items)
+	}
+
+	if len(pd.Changes) != 2 {
+		t.Errorf("expected the 2 changes between variables and constants, but got %v", pd.Changes)
+	}
+}
+
+func TestThatRemovedSentinelErrorsAreReportedOnce(t *testing.T) {
+	current := signature.PackageSignatures{
+		"a": signature.Signature{Fields: []string{"var a.ErrNotFound error", "var a.ErrTimeout error"}},
+		"b": signature.Signature{},
+	}
+	next := signature.PackageSignatures{
+		"a": signature.Signature{},
+		"b": signature.Signature{Fields: []string{"var b.ErrTimeout error"}},
+	}
+
+	d := Calculate(current, next)
+	a, b := d.Packages[0], d.Packages[1]
+
+	if a.Fields != (Diff{}) || b.Fields != (Diff{}) {
+		t.Errorf("expected the variables not to be counted as added or removed, but got %v and %v", a.Fields, b.Fields)
+	}
+
+	if len(a.Items) != 0 || len(b.Items) != 0 {
+		t.Errorf("expected no items to be listed as added or removed, but got %v and %v", a.Items, b.Items)
+	}
+
+	kinds := map[string]Kind{}
+
+	for _, c := range a.Changes {
+		kinds[c.Name] = c.Kind
+	}
+
+	expected := map[string]Kind{"ErrNotFound": SentinelErrorRemoved, "ErrTimeout": Moved}
+
+	if !reflect.DeepEqual(expected, kinds) {
+		t.Errorf("expected %v, but got %v", expected, a.Changes)
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/a-h/ver/diff"
+	"github.com/a-h/ver/git"
+)
+
+// explain recalculates the diff between a commit or tag and the commit it was compared to when the
+// history was calculated, and prints each of the changes which contributed to the version increment.
+func explain(args []string) error {
+	flags := flag.NewFlagSet("explain", flag.ContinueOnError)
+	repo := flags.String("r", "", "The git repo to clone and analyse, e.g. https://github.com/a-h/ver")
+	revision := flags.String("c", "", "The commit hash or tag to explain, e.g. 'v2.0.0'.")
+	policyName := flags.String("policy", "default", "The name of a built-in policy, or the path to a JSON policy file.")
+	optionsFlags := addOptionFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *repo == "" || *revision == "" {
+		return fmt.Errorf("please provide a repo with the -r parameter and a commit or tag with the -c parameter")
+	}
+
+	policy, err := loadPolicy(*policyName)
+	if err != nil {
+		return err
+	}
+
+	opts, err := optionsFlags.options()
+	if err != nil {
+		return err
+	}
+
+	gitRepo, err := git.Clone(*repo)
+	defer gitRepo.CleanUp()
+
+	if err != nil {
+		return fmt.Errorf("failed to clone git repo: %v", err)
+	}
+
+	if err = gitRepo.Fetch(); err != nil {
+		return fmt.Errorf("failed to fetch from git repo: %v", err)
+	}
+
+	hash, err := gitRepo.Resolve(*revision)
+	if err != nil {
+		return err
+	}
+
+	history, err := gitRepo.Log()
+	if err != nil {
+		return fmt.Errorf("failed to get the git history: %v", err)
+	}
+
+	index := findCommit(history, hash)
+
+	if index < 0 {
+		return fmt.Errorf("%s is not in the first parent history of master, so it wasn't versioned", *revision)
+	}
+
+	if index == 0 {
+		// The history gives the first commit the start version, rather than comparing it to anything.
+		fmt.Printf("Commit: %s %s\nIncrement: none\nReason: the first commit has the start version\n", history[0].Hash, history[0].Title)
+		return nil
+	}
+
+	analyseCommit := func(c git.Commit) *CommitSignature {
+		return analyse(gitRepo, c, opts)
+	}
+
+	current := analyseCommit(history[index])
+
+	var previous *CommitSignature
+
+	if current.Signature != nil {
+		previous = previousAnalysed(history[:index], analyseCommit)
+	}
+
+	if err = gitRepo.Revert(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Commit: %s %s\n", current.Hash, current.Title)
+
+	sd, approximate, ok := compareCommits(previous, current)
+
+	if !ok {
+		fmt.Printf("Increment: %v\nReason: the commit could not be compared with the previous commit, so only the build was incremented\n", policy.Commit)
+		if current.Error != nil {
+			fmt.Printf("Error: %v\n", current.Error)
+		}
+		return nil
+	}
+
+	if previous != nil {
+		fmt.Printf("Previous: %s %s\n", previous.Hash, previous.Title)
+	} else {
+		fmt.Printf("Previous: none of the earlier commits could be analysed, so the API is compared to an empty one\n")
+	}
+
+	if approximate {
+		fmt.Printf("Compared: approximate signatures, since a commit could not be type checked\n")
+	}
+
+	return writeExplanation(os.Stdout, sd, policy)
+}
+
+// previousAnalysed analyses the commits, newest first, and returns the first which has a signature,
+// or nil if none do. It's the commit which the history compares the next one to.
+func previousAnalysed(commits []git.Commit, analyse func(git.Commit) *CommitSignature) *CommitSignature {
+	for i := len(commits) - 1; i >= 0; i-- {
+		if cs := analyse(commits[i]); cs.Signature != nil {
+			return cs
+		}
+	}
+
+	return nil
+}
+
+// findCommit returns the index of the commit with the hash in the history, or -1 if it isn't in it.
+func findCommit(history []git.Commit, hash string) int {
+	for i, c := range history {
+		if c.Hash == hash {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// writeExplanation writes the version increment of the diff, followed by each of the changes
+// which contributed to it, grouped by package and kind of change, and prefixed with where the
+// item is declared, if it's known.
+func writeExplanation(w io.Writer, sd diff.SummaryDiff, policy Policy) error {
+	if _, err := fmt.Fprintf(w, "Increment: %v\nReason: %s\n", calculateVersionDelta(sd, policy), bumpReason(sd, policy)); err != nil {
+		return err
+	}
+
+	for _, pkg := range sd.Packages {
+		byKind := map[diff.Kind][]string{}
+		kinds := []string{}
+
+		for i, c := range packageChanges(pkg) {
+			// Items are counted by the diff of their element, which the policy doesn't override.
+			contribution, ok := contributionOf(c, policy, i >= len(pkg.Items))
+
+			if !ok {
+				continue
+			}
+
+			if _, seen := byKind[c.Kind]; !seen {
+				kinds = append(kinds, string(c.Kind))
+			}
+
+			line := describeChange(c) + " (" + contribution + ")"
+
+			if c.Position != nil {
+				line = c.Position.String() + ": " + line
+			}
+
+			byKind[c.Kind] = append(byKind[c.Kind], line)
+		}
+
+		if len(kinds) == 0 {
+			continue
+		}
+
+		sort.Strings(kinds)
+
+		if _, err := fmt.Fprintf(w, "\n%s\n", pkg.PackageName); err != nil {
+			return err
+		}
+
+		for _, k := range kinds {
+			if _, err := fmt.Fprintf(w, "  %s\n", k); err != nil {
+				return err
+			}
+
+			for _, line := range byKind[diff.Kind(k)] {
+				if _, err := fmt.Fprintf(w, "    %s\n", line); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// contributionOf returns how the change contributes to the version increment, e.g. "breaking",
+// or false if it doesn't. If overridable is set, the policy can override the increment for the
+// kind of change, as it does in calculateVersionDelta.
+func contributionOf(c diff.Change, policy Policy, overridable bool) (string, bool) {
+	if v, ok := policy.Changes[c.Kind]; ok && overridable {
+		return "policy: " + v.String(), true
+	}
+
+	return string(c.Impact), c.Impact == diff.Breaking || c.Impact == diff.Addition
+}
+
+// describeChange returns the item which was added or removed, or the name of the item
+// which was changed, along with the reason for the change.
+func describeChange(c diff.Change) string {
+	switch {
+	case c.Kind == diff.Added:
+		return c.Current
+	case c.Kind == diff.Removed:
+		return c.Previous
+	case c.Reason != "":
+		return c.Name + ": " + c.Reason
+	}
+
+	return c.Name + ": " + c.Previous + " -> " + c.Current
+}
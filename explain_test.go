@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/a-h/ver/diff"
+	"github.com/a-h/ver/git"
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatTheChangesWhichContributedToAVersionBumpAreExplained(t *testing.T) {
+	sd := diff.SummaryDiff{
+		Packages: []diff.PackageDiff{
+			{
+				PackageName: "github.com/a-h/example",
+				Functions:   diff.Diff{Removed: 1, Added: 1},
+				Types:       diff.Diff{Changed: 1},
+				Constants:   diff.Diff{Changed: 1},
+				Changes: []diff.Change{
+					{Kind: diff.UnderlyingTypeChanged, Name: "Mode", Impact: diff.Breaking, Reason: "changed from int to string"},
+					{Kind: diff.ValueChanged, Name: "Max", Impact: diff.Compatible, Reason: "changed from 1 to 2"},
+				},
+				Items: []diff.Change{
+					{Kind: diff.Added, Name: "New", Current: "func github.com/a-h/example.New()", Impact: diff.Addition},
+					{Kind: diff.Removed, Name: "Old", Previous: "func github.com/a-h/example.Old(s string)", Impact: diff.Breaking,
+						Position: &signature.Position{File: "example.go", Line: 12}},
+				},
+			},
+			{
+				PackageName: "github.com/a-h/example/unchanged",
+			},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := writeExplanation(buf, sd, defaultPolicy); err != nil {
+		t.Fatalf("failed to write the explanation: %v", err)
+	}
+
+	expected := `Increment: 1.1.1
+Reason: breaking: github.com/a-h/example: 1 function removed, github.com/a-h/example: underlyingTypeChanged Mode; addition: github.com/a-h/example: 1 function added
+
+github.com/a-h/example
+  added
+    func github.com/a-h/example.New() (addition)
+  removed
+    example.go:12: func github.com/a-h/example.Old(s string) (breaking)
+  underlyingTypeChanged
+    Mode: changed from int to string (breaking)
+`
+
+	if actual := buf.String(); actual != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, actual)
+	}
+}
+
+func TestThatCommitsAreExplainedAgainstTheLastCommitWhichWasAnalysed(t *testing.T) {
+	commits := []git.Commit{{Hash: "a"}, {Hash: "b"}, {Hash: "c"}}
+	analysed := []string{}
+
+	analyse := func(c git.Commit) *CommitSignature {
+		analysed = append(analysed, c.Hash)
+
+		cs := &CommitSignature{Commit: c}
+
+		switch c.Hash {
+		case "a":
+			cs.Signature = signature.PackageSignatures{}
+		case "b":
+			cs.Signature = signature.PackageSignatures{}
+			cs.Error = newCommitError(TypeCheckFailed, errors.New("failed to type check"))
+		default:
+			cs.Error = newCommitError(CheckoutFailed, errors.New("failed to check out"))
+		}
+
+		return cs
+	}
+
+	if actual := previousAnalysed(commits, analyse); actual == nil || actual.Hash != "b" {
+		t.Errorf("expected commit b to be the previous commit, but got %v", actual)
+	}
+
+	if !reflect.DeepEqual(analysed, []string{"c", "b"}) {
+		t.Errorf("expected the commits to be analysed newest first, but got %v", analysed)
+	}
+
+	if actual := previousAnalysed(commits[2:], analyse); actual != nil {
+		t.Errorf("expected no previous commit when none could be analysed, but got %v", actual)
+	}
+}
+
+func TestThatThePolicyOnlyOverridesTheContributionOfChanges(t *testing.T) {
+	sd := diff.SummaryDiff{
+		Packages: []diff.PackageDiff{
+			{
+				PackageName: "github.com/a-h/example",
+				Functions:   diff.Diff{Removed: 1},
+				Items: []diff.Change{
+					{Kind: diff.Removed, Name: "Old", Previous: "func github.com/a-h/example.Old()", Impact: diff.Breaking},
+				},
+			},
+		},
+	}
+
+	policy := Policy{
+		Commit:   Version{0, 0, 1},
+		Breaking: Version{1, 0, 0},
+		Addition: Version{0, 1, 0},
+		Changes:  map[diff.Kind]Version{diff.Removed: Version{0, 1, 0}},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := writeExplanation(buf, sd, policy); err != nil {
+		t.Fatalf("failed to write the explanation: %v", err)
+	}
+
+	expected := "Increment: 1.0.1\n"
+	line := "func github.com/a-h/example.Old() (breaking)"
+
+	if actual := buf.String(); !strings.HasPrefix(actual, expected) || !strings.Contains(actual, line) {
+		t.Errorf("expected the removal to be breaking, as it is in the increment, but got:\n%s", actual)
+	}
+}
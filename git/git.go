@@ -63,8 +63,12 @@ func (g Git) PackageDirectory() string {
 
 // Commit is the data stored within a git log output.
 type Commit struct {
-	Hash    string `json:"hash"`
+	Hash string `json:"hash"`
+	// Subject is the subject line of the commit message, sanitised for use as a file name,
+	// e.g. "Update-README.md".
 	Subject string `json:"subject"`
+	// Title is the subject line of the commit message, e.g. "Update README.md".
+	Title string `json:"title,omitempty"`
 	// Name is the author name.
 	Name      string `json:"name"`
 	Email     string `json:"email"`
@@ -90,6 +94,7 @@ func (g Git) Log() ([]Commit, error) {
 	logfmt := "--pretty=format:" +
 		"%H" + separator + // Hash
 		"%f" + separator + // Subject
+		"%s" + separator + // Title
 		"%aN" + separator + // Author Name
 		"%aE" + separator + // Author Email
 		"%ad" + separator + // Date
@@ -106,22 +111,23 @@ func (g Git) Log() ([]Commit, error) {
 	for _, line := range strings.Split(string(out), "\n") {
 		lineParts := strings.Split(line, separator)
 
-		if len(lineParts) != 6 {
+		if len(lineParts) != 7 {
 			return history, fmt.Errorf("failed to parse log line '%s', unexpected number of commit parts found", line)
 		}
 
-		ts, err := strconv.ParseInt(lineParts[5], 10, 64)
+		ts, err := strconv.ParseInt(lineParts[6], 10, 64)
 
 		if err != nil {
-			return history, fmt.Errorf("failed to parse timestamp value of '%s' for line '%s' with err %v", lineParts[5], line, err)
+			return history, fmt.Errorf("failed to parse timestamp value of '%s' for line '%s' with err %v", lineParts[6], line, err)
 		}
 
 		h := Commit{
 			Hash:    lineParts[0],
 			Subject: lineParts[1],
-			Name:    lineParts[2],
-			Email:   lineParts[3],
-			// Date: lineParts[4],
+			Title:   lineParts[2],
+			Name:    lineParts[3],
+			Email:   lineParts[4],
+			// Date: lineParts[5],
 			Timestamp: ts,
 		}
 		history = append(history, h)
@@ -145,6 +151,20 @@ func (g Git) Get(hash string) error {
 	return nil
 }
 
+// Resolve gets the hash of the commit referred to by a commit hash, tag or other revision,
+// e.g. "v1.0.0" or "v1.0.0^" for its parent.
+func (g Git) Resolve(revision string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", revision+"^{commit}")
+	cmd.Dir = g.PackageDirectory()
+	out, err := cmd.CombinedOutput()
+
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s in repo at %s with err '%v' message '%s'", revision, g.PackageDirectory(), err, string(out))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
 // Fetch the history from the remote.
 func (g Git) Fetch() error {
 	os.Chdir(g.PackageDirectory())
@@ -1,6 +1,9 @@
 package git
 
 import (
+	"io/ioutil"
+	"os"
+	"os/exec"
 	"reflect"
 	"testing"
 	"time"
@@ -29,6 +32,7 @@ func TestThatARepoCanBeCloned(t *testing.T) {
 		{
 			Hash:      "f5ea0f3b4f65fa179967d4d4d4709662ffc711b8",
 			Subject:   "First-commit",
+			Title:     "First commit",
 			Name:      "Adrian Hesketh",
 			Email:     "adrianhesketh@hushmail.com",
 			Timestamp: 1481989677,
@@ -52,3 +56,53 @@ func TestThatARepoCanBeCloned(t *testing.T) {
 		}
 	}
 }
+
+func TestThatRevisionsCanBeResolved(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ver_resolve")
+
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	g := Git{BaseLocation: dir, PackageName: "example.com/repo"}
+
+	if err = os.MkdirAll(g.PackageDirectory(), 0755); err != nil {
+		t.Fatalf("failed to create the package directory: %v", err)
+	}
+
+	commands := [][]string{
+		{"init", "-q"},
+		{"commit", "-q", "--allow-empty", "-m", "First"},
+		{"commit", "-q", "--allow-empty", "-m", "Second"},
+		{"tag", "v1.0.0"},
+	}
+
+	for _, args := range commands {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=ver", "-c", "user.email=ver@example.com"}, args...)...)
+		cmd.Dir = g.PackageDirectory()
+
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("failed to create a git repo: %v %s", err, out)
+		}
+	}
+
+	tag, err := g.Resolve("v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parent, err := g.Resolve("v1.0.0^")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tag) != 40 || len(parent) != 40 || tag == parent {
+		t.Errorf("expected the tag and its parent to resolve to different hashes, but got %s and %s", tag, parent)
+	}
+
+	if _, err = g.Resolve("v2.0.0"); err == nil {
+		t.Error("expected an error resolving a tag which doesn't exist")
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// readHistory reads the newline delimited JSON written by writeHistory.
+func readHistory(r io.Reader) ([]*CommitSignature, error) {
+	signatures := []*CommitSignature{}
+
+	dec := json.NewDecoder(r)
+
+	for {
+		cs := &CommitSignature{}
+
+		err := dec.Decode(cs)
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return signatures, fmt.Errorf("failed to read history entry %d: %v", len(signatures)+1, err)
+		}
+
+		signatures = append(signatures, cs)
+	}
+
+	return signatures, nil
+}
+
+// writeHistory writes each commit signature as a line of JSON.
+func writeHistory(w io.Writer, signatures []*CommitSignature, includeSignatures bool, includeDiffs bool) error {
+	for _, cs := range signatures {
+		output := *cs
+		if !includeSignatures {
+			output.Signature = nil
+			output.ApproximateSignature = nil
+		}
+		if !includeDiffs {
+			output.Diff = nil
+		}
+
+		j, err := json.Marshal(output)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON output for commit %s: %v", cs.Hash, err)
+		}
+
+		if _, err = w.Write(append(j, 0x0A)); err != nil {
+			return fmt.Errorf("failed to write to output: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/a-h/ver/diff"
+	"github.com/a-h/ver/git"
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatHistoryCanBeWrittenAndRead(t *testing.T) {
+	sig := signature.PackageSignatures{
+		"packageA": signature.Signature{
+			Functions: []string{"func A() string"},
+		},
+	}
+
+	signatures := []*CommitSignature{
+		&CommitSignature{
+			Commit:    git.Commit{Hash: "a", Subject: "Subject A"},
+			Package:   "github.com/a-h/example",
+			Signature: sig,
+			Version:   Version{0, 0, 0},
+		},
+		&CommitSignature{
+			Commit:  git.Commit{Hash: "b", Subject: "Subject B"},
+			Package: "github.com/a-h/example",
+			Diff: &diff.SummaryDiff{
+				Packages: []diff.PackageDiff{{PackageName: "packageA", Functions: diff.Diff{Added: 1}}},
+			},
+			Reason: "addition: packageA: 1 function added",
+			Error: &CommitError{
+				Category: TypeCheckFailed,
+				Message:  "failed",
+				TypeErrors: []signature.TypeError{
+					{Position: signature.Position{File: "a.go", Line: 1, Column: 2}, Message: "undeclared name: x"},
+				},
+			},
+			Version: Version{0, 0, 1},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := writeHistory(buf, signatures, true, true); err != nil {
+		t.Fatalf("failed to write history: %v", err)
+	}
+
+	if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+		t.Errorf("expected 2 lines of output, but got %d", lines)
+	}
+
+	actual, err := readHistory(buf)
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+
+	if len(actual) != 2 {
+		t.Fatalf("expected 2 commits to be read, but got %d", len(actual))
+	}
+
+	if actual[0].Hash != "a" || actual[0].Version != (Version{0, 0, 0}) || actual[0].Error != nil {
+		t.Errorf("unexpected first commit: %v", actual[0])
+	}
+
+	if !reflect.DeepEqual(actual[0].Signature, sig) {
+		t.Errorf("expected signature %v, but got %v", sig, actual[0].Signature)
+	}
+
+	if !reflect.DeepEqual(actual[1].Diff, signatures[1].Diff) || actual[1].Reason != signatures[1].Reason {
+		t.Errorf("expected the second commit to have diff %v and reason '%s', but got %v and '%s'",
+			signatures[1].Diff, signatures[1].Reason, actual[1].Diff, actual[1].Reason)
+	}
+
+	if actual[1].Hash != "b" || !reflect.DeepEqual(actual[1].Error, signatures[1].Error) {
+		t.Errorf("expected the second commit to have error %v, but got %v", signatures[1].Error, actual[1].Error)
+	}
+}
+
+func TestThatSignaturesAndDiffsCanBeExcludedFromHistory(t *testing.T) {
+	signatures := []*CommitSignature{
+		&CommitSignature{
+			Commit: git.Commit{Hash: "a"},
+			Signature: signature.PackageSignatures{
+				"packageA": signature.Signature{},
+			},
+			Diff: &diff.SummaryDiff{},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := writeHistory(buf, signatures, false, false); err != nil {
+		t.Fatalf("failed to write history: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "signature") {
+		t.Errorf("expected the signature to be excluded, but got %s", buf.String())
+	}
+
+	if strings.Contains(buf.String(), "diff") {
+		t.Errorf("expected the diff to be excluded, but got %s", buf.String())
+	}
+
+	actual, err := readHistory(buf)
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+
+	if err = checkHistoryHasSignatures(actual); err == nil {
+		t.Error("expected an error because the history has no signatures")
+	}
+}
+
+func TestThatHistoriesWithSignaturesInAnOlderFormatAreRejected(t *testing.T) {
+	history := `{"hash":"a","signature":{"version":1,"packages":[{"path":"a","signature":{}}]}}` + "\n"
+
+	if _, err := readHistory(strings.NewReader(history)); err == nil {
+		t.Error("expected an error reading a signature in an older format, but got nil")
+	}
+}
@@ -10,15 +10,37 @@ import (
 	"github.com/a-h/ver/git"
 	"github.com/a-h/ver/signature"
 
-	"encoding/json"
 	"net/url"
 	"os"
+	"path"
 )
 
 var repo = flag.String("r", "", "The git repo to clone and analyse, e.g. https://github.com/a-h/ver")
 var out = flag.String("o", "", "When set, outputs to a file in JSON format.")
+var includeSignatures = flag.Bool("s", false, "When set, includes the signature of each commit in the JSON output.")
+var includeDiffs = flag.Bool("d", false, "When set, includes the diff against the previous analysed commit in the JSON output.")
+var signatureDir = flag.String("sd", "", "When set, writes the signature of each commit to a JSON file named after the commit hash in the directory.")
+var optionsFlags = addOptionFlags(flag.CommandLine)
+
+// commands are run instead of analysing a repository when their name is the first argument.
+var commands = map[string]func(args []string) error{
+	"reversion":     reversion,
+	"explain":       explain,
+	"changelog":     changelog,
+	"release-notes": releaseNotes,
+}
 
 func main() {
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(-1)
+			}
+			return
+		}
+	}
+
 	flag.Parse()
 
 	if *repo == "" {
@@ -32,6 +54,12 @@ func main() {
 		os.Exit(-1)
 	}
 
+	opts, err := optionsFlags.options()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(-1)
+	}
+
 	var outFile *os.File
 	if *out != "" {
 		outFile, err = os.Create(*out)
@@ -42,6 +70,13 @@ func main() {
 		}
 	}
 
+	if *signatureDir != "" {
+		if err = os.MkdirAll(*signatureDir, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create signature directory: %v\n", err)
+			os.Exit(-1)
+		}
+	}
+
 	gitRepo, err := git.Clone(*repo)
 	defer gitRepo.CleanUp()
 
@@ -71,38 +106,18 @@ func main() {
 	for idx, h := range history {
 		fmt.Printf("Processing git log entry: %v\n", h)
 
-		cs := &CommitSignature{
-			Commit: h,
-		}
-
-		err := gitRepo.Get(h.Hash)
-
-		if err != nil {
-			cs.Error = fmt.Errorf("Failed to get commit %s: %s\n", h.Hash, err.Error())
-			signatures[idx] = cs
-			continue
-		}
-
-		err = goget(gitRepo.BaseLocation, gitRepo.PackageDirectory())
-
-		if err != nil {
-			cs.Error = err
-			signatures[idx] = cs
-			continue
-		}
+		cs := analyse(gitRepo, h, opts)
+		signatures[idx] = cs
 
-		sig, err := signature.GetFromDirectory(gitRepo.BaseLocation, gitRepo.PackageDirectory())
+		// Approximate signatures are saved too, and are marked as approximate.
+		if *signatureDir != "" && cs.Signature != nil {
+			err = signature.Save(path.Join(*signatureDir, h.Hash+".json"), cs.Signature)
 
-		if err != nil {
-			cs.Error = fmt.Errorf("Failed to get signatures of package at commit %s: %s\n",
-				h.Hash, err.Error())
-			signatures[idx] = cs
-			continue
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to write the signature of commit %s: %s\n", h.Hash, err.Error())
+			}
 		}
 
-		cs.Signature = sig
-		signatures[idx] = cs
-
 		err = gitRepo.Revert()
 
 		if err != nil {
@@ -120,7 +135,9 @@ func main() {
 
 	fmt.Printf("About to calculate signatures...\n")
 
-	addPackageNameAndVersionToSignatures(signatures, repoURL.Host+repoURL.Path)
+	keepApproximateSignatures(signatures)
+
+	addPackageNameAndVersionToSignatures(signatures, repoURL.Host+repoURL.Path, Version{}, defaultPolicy)
 
 	for _, cs := range signatures {
 		fmt.Println()
@@ -128,19 +145,14 @@ func main() {
 		fmt.Printf("Subject: %s\n", cs.Commit.Subject)
 		fmt.Printf("Date: %v\n", cs.Commit.Date())
 		fmt.Printf("Version: %v\n", cs.Version)
-		if err != nil {
+		if cs.Error != nil {
 			fmt.Printf("Error: %v\n", cs.Error)
 		}
-		if outFile != nil {
-			j, err := json.Marshal(cs)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to marshal JSON output: %v", err)
-			}
-			_, err = outFile.Write(j)
-			outFile.Write([]byte{0x0A})
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to write to output file: %v", err)
-			}
+	}
+
+	if outFile != nil {
+		if err = writeHistory(outFile, signatures, *includeSignatures, *includeDiffs); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write to output file: %v", err)
 		}
 	}
 }
@@ -171,29 +183,102 @@ func goget(gopath string, location string) error {
 	return nil
 }
 
-func addPackageNameAndVersionToSignatures(signatures []*CommitSignature, packageName string) {
-	version := Version{}
+// analyse gets the signature of a commit. If the code can't be type checked, the approximate
+// signature is used, and the error is recorded.
+func analyse(gitRepo git.Git, h git.Commit, opts signature.Options) *CommitSignature {
+	cs := &CommitSignature{
+		Commit: h,
+	}
+
+	err := gitRepo.Get(h.Hash)
+
+	if err != nil {
+		cs.Error = newCommitError(CheckoutFailed, fmt.Errorf("failed to get commit %s: %v", h.Hash, err))
+		return cs
+	}
+
+	err = goget(gitRepo.BaseLocation, gitRepo.PackageDirectory())
+
+	if err != nil {
+		cs.Error = newCommitError(DependencyFetchFailed, err)
+		cs.Signature = getApproximateSignature(gitRepo, opts)
+		return cs
+	}
+
+	sig, err := signature.GetFromDirectoryWithOptions(gitRepo.BaseLocation, gitRepo.PackageDirectory(), opts)
+
+	if err != nil {
+		cs.Error = newSignatureError(err)
+		if cs.Error.Category == TypeCheckFailed {
+			cs.Signature = getApproximateSignature(gitRepo, opts)
+		}
+		return cs
+	}
+
+	cs.Signature = sig
+
+	// The approximate signature is compared with the approximate signature of neighbouring commits
+	// which can't be type checked.
+	cs.ApproximateSignature = getApproximateSignature(gitRepo, opts)
+
+	return cs
+}
+
+// getApproximateSignature reads the signature of code which can't be type checked,
+// returning nil if even that isn't possible.
+func getApproximateSignature(gitRepo git.Git, opts signature.Options) signature.PackageSignatures {
+	sig, err := signature.GetApproximateFromDirectory(gitRepo.BaseLocation, gitRepo.PackageDirectory(), opts)
+
+	if err != nil {
+		return nil
+	}
+
+	return sig
+}
+
+func addPackageNameAndVersionToSignatures(signatures []*CommitSignature, packageName string, start Version, policy Policy) {
+	version := start
 
 	if len(signatures) > 0 {
-		previous := signatures[0]
-		previous.Package = packageName
-		previous.Version = version
+		signatures[0].Package = packageName
+		signatures[0].Version = version
+
+		// Each commit is compared to the last commit which has a signature. If none do, it's
+		// compared to an empty signature, so its API counts as added.
+		var previous *CommitSignature
+
+		if signatures[0].Signature != nil {
+			previous = signatures[0]
+		}
 
 		for _, current := range signatures[1:] {
 			current.Package = packageName
-			if current.Error != nil {
-				// Add 1 to the build, even though it wasn't successfully handled.
-				version = version.Add(Version{Build: 1})
+
+			sd, approximate, ok := compareCommits(previous, current)
+
+			if !ok {
+				// Increment the build, even though it wasn't successfully handled.
+				version = version.Add(policy.Commit)
 				current.Version = version
+				current.Diff = nil
+				current.Reason = "the commit could not be compared with the previous commit, so only the build was incremented"
+
+				if current.Signature != nil {
+					previous = current
+				}
 				continue
 			}
 
-			// Calculate the diff against the previous version.
-			diff := diff.Calculate(previous.Signature, current.Signature)
 			// Work out what the version increment should be.
-			delta := calculateVersionDelta(diff)
+			delta := calculateVersionDelta(sd, policy)
 			version = version.Add(delta)
 			current.Version = version
+			current.Diff = &sd
+			current.Reason = bumpReason(sd, policy)
+
+			if approximate {
+				current.Reason = "approximate: " + current.Reason
+			}
 
 			// Update the previous version.
 			previous = current
@@ -201,13 +286,95 @@ func addPackageNameAndVersionToSignatures(signatures []*CommitSignature, package
 	}
 }
 
-func calculateVersionDelta(sd diff.SummaryDiff) Version {
-	d := Version{
-		Build: 1, // Always increment the build.
+// compareCommits calculates the diff between the signatures of two commits. If either commit
+// couldn't be type checked, the approximate signatures of both are compared, since they render
+// the same API the same way, and approximate is set. If previous is nil, the current commit is
+// compared to an empty signature. It returns false if the signatures can't be compared.
+func compareCommits(previous *CommitSignature, current *CommitSignature) (sd diff.SummaryDiff, approximate bool, ok bool) {
+	if current.Signature == nil {
+		return sd, false, false
+	}
+
+	if current.typeChecked() && (previous == nil || previous.typeChecked()) {
+		var before signature.PackageSignatures
+
+		if previous != nil {
+			before = previous.Signature
+		}
+
+		return diff.Calculate(before, current.Signature), false, true
+	}
+
+	var before signature.PackageSignatures
+
+	if previous != nil {
+		if before = previous.approximate(); before == nil {
+			return sd, true, false
+		}
+	}
+
+	after := current.approximate()
+
+	if after == nil {
+		return sd, true, false
+	}
+
+	return diff.Calculate(before, after), true, true
+}
+
+// keepApproximateSignatures removes the approximate signatures of commits which could be type
+// checked, unless they're needed to compare the commit with the commit before or after it, which
+// couldn't be.
+func keepApproximateSignatures(signatures []*CommitSignature) {
+	for i, cs := range signatures {
+		if !cs.typeChecked() {
+			continue
+		}
+
+		needed := false
+
+		if next := firstWithSignature(signatures[i+1:]); next != nil && !next.typeChecked() {
+			needed = true
+		}
+
+		if previous := lastWithSignature(signatures[:i]); previous != nil && !previous.typeChecked() {
+			needed = true
+		}
+
+		if !needed {
+			cs.ApproximateSignature = nil
+		}
+	}
+}
+
+// firstWithSignature returns the first commit which has a signature, or nil if none of them do.
+func firstWithSignature(signatures []*CommitSignature) *CommitSignature {
+	for _, cs := range signatures {
+		if cs.Signature != nil {
+			return cs
+		}
+	}
+
+	return nil
+}
+
+// lastWithSignature returns the last commit which has a signature, or nil if none of them do.
+func lastWithSignature(signatures []*CommitSignature) *CommitSignature {
+	for i := len(signatures) - 1; i >= 0; i-- {
+		if signatures[i].Signature != nil {
+			return signatures[i]
+		}
 	}
 
+	return nil
+}
+
+func calculateVersionDelta(sd diff.SummaryDiff, policy Policy) Version {
+	d := policy.Commit // Always increment the build.
+
 	binaryCompatibilityBroken := false
 	newExportedData := false
+	overridden := Version{}
 
 	if sd.PackageChanges.Added > 0 {
 		newExportedData = true
@@ -223,17 +390,29 @@ func calculateVersionDelta(sd diff.SummaryDiff) Version {
 		updateBasedOn(pkg.Functions, &binaryCompatibilityBroken, &newExportedData)
 		updateBasedOn(pkg.Interfaces, &binaryCompatibilityBroken, &newExportedData)
 		updateBasedOn(pkg.Structs, &binaryCompatibilityBroken, &newExportedData)
+		updateBasedOn(pkg.Types, &binaryCompatibilityBroken, &newExportedData)
+
+		for _, c := range pkg.Changes {
+			// The policy can override the increment for a kind of change.
+			if v, ok := policy.Changes[c.Kind]; ok {
+				overridden = overridden.Max(v)
+				continue
+			}
+			updateBasedOnImpact(c.Impact, &binaryCompatibilityBroken, &newExportedData)
+		}
 	}
 
+	increment := overridden
+
 	if binaryCompatibilityBroken {
-		d.Major = 1
+		increment = increment.Max(policy.Breaking)
 	}
 
 	if newExportedData {
-		d.Minor = 1
+		increment = increment.Max(policy.Addition)
 	}
 
-	return d
+	return d.Add(increment)
 }
 
 func updateBasedOn(d diff.Diff, binaryCompatibilityBroken *bool, newExportedData *bool) {
@@ -246,11 +425,44 @@ func updateBasedOn(d diff.Diff, binaryCompatibilityBroken *bool, newExportedData
 	}
 }
 
+func updateBasedOnImpact(impact diff.Impact, binaryCompatibilityBroken *bool, newExportedData *bool) {
+	switch impact {
+	case diff.Breaking:
+		*binaryCompatibilityBroken = true
+	case diff.Addition:
+		*newExportedData = true
+	}
+}
+
 // CommitSignature is the signature of a commit.
 type CommitSignature struct {
 	git.Commit
 	Package   string                      `json:"pkg"`
-	Signature signature.PackageSignatures `json:"-"`
-	Error     error                       `json:"error"`
+	Signature signature.PackageSignatures `json:"signature,omitempty"`
+	Error     *CommitError                `json:"error"`
 	Version   Version                     `json:"v"`
+	// Diff is the difference between the API of the commit and the previous analysed commit.
+	Diff *diff.SummaryDiff `json:"diff,omitempty"`
+	// Reason describes why the version was incremented.
+	Reason string `json:"reason,omitempty"`
+	// ApproximateSignature is the signature of a commit which could be type checked, read from the
+	// source without type checking it. It's only kept when the commit is compared with one which
+	// couldn't be type checked.
+	ApproximateSignature signature.PackageSignatures `json:"approximateSignature,omitempty"`
+}
+
+// typeChecked returns true if the signature of the commit was calculated by type checking
+// it, rather than being approximated from the source, or missing.
+func (cs *CommitSignature) typeChecked() bool {
+	return cs.Error == nil && cs.Signature != nil
+}
+
+// approximate returns the signature of the commit read from the source without type checking it,
+// or nil if it isn't available.
+func (cs *CommitSignature) approximate() signature.PackageSignatures {
+	if cs.typeChecked() {
+		return cs.ApproximateSignature
+	}
+
+	return cs.Signature
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"io/ioutil"
 	"os"
 	"path"
 	"strings"
@@ -112,14 +113,117 @@ func TestThatVersionDeltasCanBeCalculated(t *testing.T) {
 			},
 			expected: Version{Major: 0, Minor: 1, Build: 1},
 		},
+		{
+			name: "Function no longer available on a platform",
+			sd: diff.SummaryDiff{
+				Packages: []diff.PackageDiff{
+					diff.PackageDiff{
+						Changes: []diff.Change{{Kind: diff.PlatformRemoved, Impact: diff.Breaking}},
+					},
+				},
+			},
+			expected: Version{Major: 1, Minor: 0, Build: 1},
+		},
+		{
+			name: "Type added",
+			sd: diff.SummaryDiff{
+				Packages: []diff.PackageDiff{
+					diff.PackageDiff{
+						Types: diff.Diff{Added: 1},
+					},
+				},
+			},
+			expected: Version{Major: 0, Minor: 1, Build: 1},
+		},
+		{
+			name: "Underlying type changed",
+			sd: diff.SummaryDiff{
+				Packages: []diff.PackageDiff{
+					diff.PackageDiff{
+						Types:   diff.Diff{Changed: 1},
+						Changes: []diff.Change{{Kind: diff.UnderlyingTypeChanged, Impact: diff.Breaking}},
+					},
+				},
+			},
+			expected: Version{Major: 1, Minor: 0, Build: 1},
+		},
 	}
 	for _, tt := range tests {
-		if actual := calculateVersionDelta(tt.sd); tt.expected != actual {
+		if actual := calculateVersionDelta(tt.sd, defaultPolicy); tt.expected != actual {
 			t.Errorf("%q. Expected version %s, but got %s", tt.name, tt.expected, actual)
 		}
 	}
 }
 
+func TestThatThePolicyDeterminesTheVersionDelta(t *testing.T) {
+	sd := diff.SummaryDiff{
+		Packages: []diff.PackageDiff{
+			diff.PackageDiff{
+				Functions: diff.Diff{Added: 1, Removed: 1},
+			},
+		},
+	}
+
+	tests := []struct {
+		policy   string
+		expected Version
+	}{
+		{
+			policy:   "default",
+			expected: Version{Major: 1, Minor: 1, Build: 1},
+		},
+		{
+			policy:   "unstable",
+			expected: Version{Major: 0, Minor: 1, Build: 1},
+		},
+	}
+	for _, tt := range tests {
+		p, err := loadPolicy(tt.policy)
+		if err != nil {
+			t.Fatalf("failed to load policy %s: %v", tt.policy, err)
+		}
+		if actual := calculateVersionDelta(sd, p); tt.expected != actual {
+			t.Errorf("%q. Expected version %s, but got %s", tt.policy, tt.expected, actual)
+		}
+	}
+}
+
+func TestThatThePolicyCanOverrideTheIncrementForAKindOfChange(t *testing.T) {
+	sd := diff.SummaryDiff{
+		Packages: []diff.PackageDiff{
+			diff.PackageDiff{
+				Constants: diff.Diff{Changed: 1},
+				Changes:   []diff.Change{{Kind: diff.ValueChanged, Impact: diff.Compatible}},
+			},
+		},
+	}
+
+	if actual := calculateVersionDelta(sd, defaultPolicy); actual != (Version{Build: 1}) {
+		t.Errorf("expected a value change to only increment the build by default, but got %s", actual)
+	}
+
+	f, err := ioutil.TempFile("", "ver_policy")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(`{"breaking":"1.0.0","addition":"0.1.0","commit":"0.0.1","changes":{"valueChanged":"0.1.0"}}`)
+	f.Close()
+	if err != nil {
+		t.Fatalf("failed to write policy: %v", err)
+	}
+
+	p, err := loadPolicy(f.Name())
+	if err != nil {
+		t.Fatalf("failed to load policy: %v", err)
+	}
+
+	if actual := calculateVersionDelta(sd, p); actual != (Version{Minor: 1, Build: 1}) {
+		t.Errorf("expected the policy to increment the minor version, but got %s", actual)
+	}
+}
+
 func TestAddPackageNameAndVersionToSignaturesFunction(t *testing.T) {
 	a := CommitSignature{}
 	a.Hash = "a"
@@ -150,7 +254,7 @@ func TestAddPackageNameAndVersionToSignaturesFunction(t *testing.T) {
 	signatures := []*CommitSignature{&a, &b}
 
 	expectedPackageName := "github.com/a-h/example"
-	addPackageNameAndVersionToSignatures(signatures, expectedPackageName)
+	addPackageNameAndVersionToSignatures(signatures, expectedPackageName, Version{}, defaultPolicy)
 
 	expectedVersion := Version{0, 0, 0}
 	if a.Version != expectedVersion {
@@ -167,4 +271,123 @@ func TestAddPackageNameAndVersionToSignaturesFunction(t *testing.T) {
 	if b.Package != expectedPackageName {
 		t.Errorf("(2) expected package name %v, but was '%v'", expectedPackageName, b.Package)
 	}
+
+	if b.Diff == nil || len(b.Diff.Packages) != 1 {
+		t.Errorf("expected the second commit to include the diff against the first, but got %v", b.Diff)
+	}
+
+	if b.Reason != "no exported API changes" {
+		t.Errorf("expected the second commit to have no exported API changes, but got reason '%s'", b.Reason)
+	}
+}
+
+func TestThatCommitsWhichCouldNotBeTypeCheckedAreComparedWithApproximateSignatures(t *testing.T) {
+	typeChecked := signature.PackageSignatures{
+		"packageA": signature.Signature{
+			Constants: []string{"const packageA.A packageA.Mode = 0", "const packageA.B packageA.Mode = 1"},
+			Functions: []string{"func packageA.New() packageA.Mode"},
+		},
+	}
+
+	// The approximate signature renders the same API differently.
+	approximate := signature.PackageSignatures{
+		"packageA": signature.Signature{
+			Constants:   []string{"const packageA.A packageA.Mode = iota", "const packageA.B"},
+			Functions:   []string{"func packageA.New() packageA.Mode"},
+			Approximate: true,
+		},
+	}
+
+	added := signature.PackageSignatures{
+		"packageA": signature.Signature{
+			Constants:   []string{"const packageA.A packageA.Mode = iota", "const packageA.B"},
+			Functions:   []string{"func packageA.New() packageA.Mode", "func packageA.Parse(s string) packageA.Mode"},
+			Approximate: true,
+		},
+	}
+
+	typeCheckedWithParse := signature.PackageSignatures{
+		"packageA": signature.Signature{
+			Constants: []string{"const packageA.A packageA.Mode = 0", "const packageA.B packageA.Mode = 1"},
+			Functions: []string{"func packageA.New() packageA.Mode", "func packageA.Parse(s string) packageA.Mode"},
+		},
+	}
+
+	broken := &CommitError{Category: TypeCheckFailed}
+
+	tests := []struct {
+		name       string
+		signatures []*CommitSignature
+		expected   []Version
+	}{
+		{
+			name: "Unchanged API across a commit which couldn't be type checked",
+			signatures: []*CommitSignature{
+				{Signature: typeChecked, ApproximateSignature: approximate},
+				{Signature: approximate, Error: broken},
+				{Signature: typeChecked, ApproximateSignature: approximate},
+			},
+			expected: []Version{{0, 0, 0}, {0, 0, 1}, {0, 0, 2}},
+		},
+		{
+			name: "Function added by a commit which couldn't be type checked",
+			signatures: []*CommitSignature{
+				{Signature: typeChecked, ApproximateSignature: approximate},
+				{Signature: added, Error: broken},
+				{Signature: typeCheckedWithParse, ApproximateSignature: added},
+			},
+			expected: []Version{{0, 0, 0}, {0, 1, 1}, {0, 1, 2}},
+		},
+		{
+			name: "Commit which couldn't be analysed at all",
+			signatures: []*CommitSignature{
+				{Signature: typeChecked, ApproximateSignature: approximate},
+				{Error: &CommitError{Category: CheckoutFailed}},
+				{Signature: typeCheckedWithParse},
+			},
+			expected: []Version{{0, 0, 0}, {0, 0, 1}, {0, 1, 2}},
+		},
+		{
+			name: "Approximate signature of the previous commit missing",
+			signatures: []*CommitSignature{
+				{Signature: typeChecked},
+				{Signature: added, Error: broken},
+			},
+			expected: []Version{{0, 0, 0}, {0, 0, 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		addPackageNameAndVersionToSignatures(tt.signatures, "github.com/a-h/example", Version{}, defaultPolicy)
+
+		for i, cs := range tt.signatures {
+			if cs.Version != tt.expected[i] {
+				t.Errorf("%q. commit %d: expected version %v, but got %v (%s)", tt.name, i, tt.expected[i], cs.Version, cs.Reason)
+			}
+		}
+	}
+}
+
+func TestThatApproximateSignaturesAreOnlyKeptWhenTheyAreNeeded(t *testing.T) {
+	sig := signature.PackageSignatures{"packageA": signature.Signature{}}
+	approximate := signature.PackageSignatures{"packageA": signature.Signature{Approximate: true}}
+
+	signatures := []*CommitSignature{
+		{Signature: sig, ApproximateSignature: approximate},
+		{Signature: sig, ApproximateSignature: approximate},
+		{Error: &CommitError{Category: CheckoutFailed}},
+		{Signature: approximate, Error: &CommitError{Category: TypeCheckFailed}},
+		{Signature: sig, ApproximateSignature: approximate},
+		{Signature: sig, ApproximateSignature: approximate},
+	}
+
+	keepApproximateSignatures(signatures)
+
+	expected := []bool{false, true, false, true, true, false}
+
+	for i, cs := range signatures {
+		if kept := cs.ApproximateSignature != nil || (cs.Signature != nil && !cs.typeChecked()); kept != expected[i] {
+			t.Errorf("commit %d: expected the approximate signature to be kept: %v, but got %v", i, expected[i], kept)
+		}
+	}
 }
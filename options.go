@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/a-h/ver/signature"
+)
+
+// optionFlags are the flags which decide what is included in the signature of a commit. They're
+// shared by each of the commands which analyse a repository, so that they calculate the same API.
+type optionFlags struct {
+	excludeGenerated *bool
+	includeInternal  *bool
+	includeMain      *bool
+	layout           *bool
+	initializers     *bool
+	exclude          stringsFlag
+	platforms        stringsFlag
+	interfaces       stringsFlag
+}
+
+// addOptionFlags defines the flags which decide what is included in a signature on the flag set.
+func addOptionFlags(flags *flag.FlagSet) *optionFlags {
+	f := &optionFlags{
+		excludeGenerated: flags.Bool("exclude-generated", false, "When set, excludes files marked with a '// Code generated ... DO NOT EDIT.' comment."),
+		includeInternal:  flags.Bool("include-internal", false, "When set, includes internal packages in the API."),
+		includeMain:      flags.Bool("include-main", false, "When set, includes main packages in the API."),
+		layout:           flags.Bool("layout", false, "When set, records the size, alignment and field offsets of structs for the architecture of each platform."),
+		initializers:     flags.Bool("initializers", false, "When set, records the expression each exported variable is initialized with, and reports changes to it."),
+	}
+
+	flags.Var(&f.exclude, "exclude", "A glob pattern of files or directories to exclude, e.g. '*_mock.go'. Can be used multiple times.")
+	flags.Var(&f.platforms, "platform", "A platform to calculate the API for, e.g. 'linux/amd64' or 'linux/amd64:tag1,tag2'. Can be used multiple times.")
+	flags.Var(&f.interfaces, "interface", "A well-known interface to record the implementations of, e.g. 'io.Reader' or 'encoding/json.Marshaler'. Can be used multiple times. Defaults to a set of common standard library interfaces.")
+
+	return f
+}
+
+// options returns the signature options set by the flags.
+func (f *optionFlags) options() (signature.Options, error) {
+	opts := signature.Options{
+		ExcludeGenerated: *f.excludeGenerated,
+		Exclude:          f.exclude,
+		IncludeInternal:  *f.includeInternal,
+		IncludeMain:      *f.includeMain,
+		Layout:           *f.layout,
+		Initializers:     *f.initializers,
+	}
+
+	if len(f.interfaces) > 0 {
+		opts.Interfaces = f.interfaces
+	}
+
+	for _, p := range f.platforms {
+		platform, err := signature.ParsePlatform(p)
+		if err != nil {
+			return opts, err
+		}
+		opts.Platforms = append(opts.Platforms, platform)
+	}
+
+	return opts, nil
+}
+
+// stringsFlag is a flag which can be provided multiple times.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/a-h/ver/signature"
+)
+
+func TestThatOptionFlagsAreConvertedToSignatureOptions(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	optionsFlags := addOptionFlags(flags)
+
+	args := []string{
+		"-exclude-generated", "-include-internal", "-include-main", "-layout", "-initializers",
+		"-exclude", "*_mock.go", "-exclude", "vendor",
+		"-interface", "io.Reader",
+		"-platform", "linux/amd64",
+	}
+
+	if err := flags.Parse(args); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+
+	actual, err := optionsFlags.options()
+
+	if err != nil {
+		t.Fatalf("failed to get options: %v", err)
+	}
+
+	expected := signature.Options{
+		ExcludeGenerated: true,
+		Exclude:          []string{"*_mock.go", "vendor"},
+		IncludeInternal:  true,
+		IncludeMain:      true,
+		Layout:           true,
+		Initializers:     true,
+		Interfaces:       []string{"io.Reader"},
+		Platforms:        []signature.Platform{{GOOS: "linux", GOARCH: "amd64"}},
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %+v, but got %+v", expected, actual)
+	}
+}
+
+func TestThatInvalidPlatformsAreRejected(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	optionsFlags := addOptionFlags(flags)
+
+	if err := flags.Parse([]string{"-platform", "linux"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+
+	if _, err := optionsFlags.options(); err == nil {
+		t.Error("expected an error for an invalid platform, but got nil")
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/a-h/ver/diff"
+)
+
+// Policy determines how the version is incremented when the exported API changes.
+type Policy struct {
+	// Breaking is added to the version when binary compatibility is broken.
+	Breaking Version `json:"breaking"`
+	// Addition is added to the version when new exported items are added.
+	Addition Version `json:"addition"`
+	// Commit is added to the version on every commit.
+	Commit Version `json:"commit"`
+	// Changes overrides the increment for kinds of change, e.g. {"valueChanged": "0.1.0"}
+	// increments the minor version when the value of a constant changes, instead of
+	// treating it as compatible.
+	Changes map[diff.Kind]Version `json:"changes,omitempty"`
+}
+
+// defaultPolicy increments the major version when binary compatibility is broken,
+// and the minor version when new exported items are added.
+var defaultPolicy = Policy{
+	Breaking: Version{Major: 1},
+	Addition: Version{Minor: 1},
+	Commit:   Version{Build: 1},
+}
+
+// policies are the built-in policies which can be selected by name.
+var policies = map[string]Policy{
+	"default": defaultPolicy,
+	// unstable treats the API as a pre-1.0 API, where breaking changes only
+	// increment the minor version.
+	"unstable": {
+		Breaking: Version{Minor: 1},
+		Addition: Version{Minor: 1},
+		Commit:   Version{Build: 1},
+	},
+}
+
+// loadPolicy returns the built-in policy with the given name, or reads a policy
+// from a JSON file, e.g. {"breaking":"1.0.0","addition":"0.1.0","commit":"0.0.1","changes":{"valueChanged":"0.1.0"}}.
+func loadPolicy(nameOrFile string) (Policy, error) {
+	if p, ok := policies[nameOrFile]; ok {
+		return p, nil
+	}
+
+	b, err := ioutil.ReadFile(nameOrFile)
+
+	if err != nil {
+		return Policy{}, fmt.Errorf("'%s' is not one of the built-in policies (%s) and could not be read as a file: %v",
+			nameOrFile, strings.Join(policyNames(), ", "), err)
+	}
+
+	p := Policy{}
+
+	if err = json.Unmarshal(b, &p); err != nil {
+		return Policy{}, fmt.Errorf("failed to read policy file %s: %v", nameOrFile, err)
+	}
+
+	return p, nil
+}
+
+func policyNames() []string {
+	names := make([]string, 0, len(policies))
+
+	for name := range policies {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/a-h/ver/diff"
+)
+
+// bumpReason describes why calculateVersionDelta incremented the version, e.g.
+// "breaking: github.com/a-h/ver/diff: 1 function removed".
+func bumpReason(sd diff.SummaryDiff, policy Policy) string {
+	var breaking, additions, overridden []string
+
+	if sd.PackageChanges.Removed > 0 {
+		breaking = append(breaking, count(sd.PackageChanges.Removed, "package")+" removed")
+	}
+
+	if sd.PackageChanges.Added > 0 {
+		additions = append(additions, count(sd.PackageChanges.Added, "package")+" added")
+	}
+
+	for _, pkg := range sd.Packages {
+		for _, e := range packageElements(pkg) {
+			if e.diff.Removed > 0 {
+				breaking = append(breaking, pkg.PackageName+": "+count(e.diff.Removed, e.name)+" removed")
+			}
+
+			if e.diff.Added > 0 {
+				additions = append(additions, pkg.PackageName+": "+count(e.diff.Added, e.name)+" added")
+			}
+		}
+
+		for _, c := range pkg.Changes {
+			description := pkg.PackageName + ": " + string(c.Kind) + " " + c.Name
+
+			if v, ok := policy.Changes[c.Kind]; ok {
+				overridden = append(overridden, description+" ("+v.String()+")")
+				continue
+			}
+
+			switch c.Impact {
+			case diff.Breaking:
+				breaking = append(breaking, description)
+			case diff.Addition:
+				additions = append(additions, description)
+			}
+		}
+	}
+
+	var reasons []string
+
+	if len(breaking) > 0 {
+		reasons = append(reasons, "breaking: "+strings.Join(breaking, ", "))
+	}
+
+	if len(additions) > 0 {
+		reasons = append(reasons, "addition: "+strings.Join(additions, ", "))
+	}
+
+	if len(overridden) > 0 {
+		reasons = append(reasons, "policy: "+strings.Join(overridden, ", "))
+	}
+
+	if len(reasons) == 0 {
+		return "no exported API changes"
+	}
+
+	return strings.Join(reasons, "; ")
+}
+
+type packageElement struct {
+	name string
+	diff diff.Diff
+}
+
+// packageElements returns the diff of each element of the package, named in the singular.
+func packageElements(pkg diff.PackageDiff) []packageElement {
+	return []packageElement{
+		{name: "constant", diff: pkg.Constants},
+		{name: "field", diff: pkg.Fields},
+		{name: "function", diff: pkg.Functions},
+		{name: "interface", diff: pkg.Interfaces},
+		{name: "struct", diff: pkg.Structs},
+		{name: "type", diff: pkg.Types},
+	}
+}
+
+// count returns n followed by the noun, pluralised if required, e.g. "2 functions".
+func count(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", n, noun)
+}
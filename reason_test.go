@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/a-h/ver/diff"
+)
+
+func TestThatTheReasonForAVersionBumpIsDescribed(t *testing.T) {
+	tests := []struct {
+		name     string
+		sd       diff.SummaryDiff
+		policy   Policy
+		expected string
+	}{
+		{
+			name:     "No changes",
+			sd:       diff.SummaryDiff{},
+			policy:   defaultPolicy,
+			expected: "no exported API changes",
+		},
+		{
+			name: "Package removed and function added",
+			sd: diff.SummaryDiff{
+				PackageChanges: diff.Diff{Removed: 1},
+				Packages: []diff.PackageDiff{
+					{PackageName: "packageA", Functions: diff.Diff{Added: 2}},
+				},
+			},
+			policy:   defaultPolicy,
+			expected: "breaking: 1 package removed; addition: packageA: 2 functions added",
+		},
+		{
+			name: "Item-level changes",
+			sd: diff.SummaryDiff{
+				Packages: []diff.PackageDiff{
+					{
+						PackageName: "packageA",
+						Changes: []diff.Change{
+							{Kind: diff.UnderlyingTypeChanged, Name: "Mode", Impact: diff.Breaking},
+							{Kind: diff.ValueChanged, Name: "Max", Impact: diff.Compatible},
+						},
+					},
+				},
+			},
+			policy:   defaultPolicy,
+			expected: "breaking: packageA: underlyingTypeChanged Mode",
+		},
+		{
+			name: "Overridden by the policy",
+			sd: diff.SummaryDiff{
+				Packages: []diff.PackageDiff{
+					{
+						PackageName: "packageA",
+						Changes:     []diff.Change{{Kind: diff.ValueChanged, Name: "Max", Impact: diff.Compatible}},
+					},
+				},
+			},
+			policy: Policy{
+				Commit:  Version{Build: 1},
+				Changes: map[diff.Kind]Version{diff.ValueChanged: Version{Minor: 1}},
+			},
+			expected: "policy: packageA: valueChanged Max (0.1.0)",
+		},
+	}
+
+	for _, tt := range tests {
+		if actual := bumpReason(tt.sd, tt.policy); actual != tt.expected {
+			t.Errorf("%q. Expected reason '%s', but got '%s'", tt.name, tt.expected, actual)
+		}
+	}
+}
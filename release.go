@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/a-h/ver/diff"
+	"github.com/a-h/ver/git"
+)
+
+// release describes the changes to the API between two commits of a history. It's the data
+// passed to release notes templates.
+type release struct {
+	// Version is the version of the last commit in the release.
+	Version Version
+	// Previous is the version of the commit before the release.
+	Previous Version
+	// Commit is the last commit in the release.
+	Commit git.Commit
+	// Commits are the commits in the release, oldest first.
+	Commits []git.Commit
+	// Diff is the difference between the API before and after the release.
+	Diff diff.SummaryDiff
+	// Changes are the changes in the Diff, along with the commits which made them.
+	Changes []releaseChange
+}
+
+// releaseChange is a change to the API, along with the commits which made it.
+type releaseChange struct {
+	diff.Change
+	Package string
+	Commits []git.Commit
+}
+
+// newRelease calculates the changes made by the commits after from, up to and including to. Each
+// of from and to is a commit hash, or a version, e.g. "1.2.0". If from isn't set, the release starts
+// after the first commit, and if to isn't set, it ends at the last commit.
+func newRelease(signatures []*CommitSignature, from string, to string) (release, error) {
+	if len(signatures) < 2 {
+		return release{}, fmt.Errorf("the history must include at least 2 commits")
+	}
+
+	fromIndex, toIndex := 0, len(signatures)-1
+
+	var err error
+
+	if from != "" {
+		if fromIndex, err = findInHistory(signatures, from); err != nil {
+			return release{}, err
+		}
+	}
+
+	if to != "" {
+		if toIndex, err = findInHistory(signatures, to); err != nil {
+			return release{}, err
+		}
+	}
+
+	if fromIndex >= toIndex {
+		return release{}, fmt.Errorf("%s must be after %s in the history", signatures[toIndex].Hash, signatures[fromIndex].Hash)
+	}
+
+	base, head := lastAnalysed(signatures[:fromIndex+1]), lastAnalysed(signatures[:toIndex+1])
+
+	if base == nil || head == nil {
+		return release{}, fmt.Errorf("the history does not include signatures of commits which could be type checked, please write it with the -s flag")
+	}
+
+	r := release{
+		Version:  signatures[toIndex].Version,
+		Previous: signatures[fromIndex].Version,
+		Commit:   signatures[toIndex].Commit,
+		Diff:     diff.Calculate(base.Signature, head.Signature),
+	}
+
+	// Attribute each change to the commits which changed the same item.
+	commits := map[string][]git.Commit{}
+	previous := base
+
+	for _, cs := range signatures[fromIndex+1 : toIndex+1] {
+		r.Commits = append(r.Commits, cs.Commit)
+
+		// The approximate signature of a commit which couldn't be type checked would attribute
+		// changes to it which weren't made.
+		if !cs.typeChecked() {
+			continue
+		}
+
+		for _, pd := range diff.Calculate(previous.Signature, cs.Signature).Packages {
+			for _, c := range packageChanges(pd) {
+				key := changeKey(pd.PackageName, c)
+				commits[key] = append(commits[key], cs.Commit)
+			}
+		}
+
+		previous = cs
+	}
+
+	for _, pd := range r.Diff.Packages {
+		for _, c := range packageChanges(pd) {
+			r.Changes = append(r.Changes, releaseChange{
+				Change:  c,
+				Package: pd.PackageName,
+				Commits: commits[changeKey(pd.PackageName, c)],
+			})
+		}
+	}
+
+	return r, nil
+}
+
+// Breaking returns the changes which can stop code which uses the packages from compiling,
+// each of which explains why.
+func (r release) Breaking() []releaseChange {
+	var rv []releaseChange
+
+	for _, c := range r.Changes {
+		if c.Impact == diff.Breaking {
+			rv = append(rv, c)
+		}
+	}
+
+	return rv
+}
+
+// findInHistory returns the index of the commit with the hash, or a prefix of at least 7
+// characters of it, or with the version.
+func findInHistory(signatures []*CommitSignature, hashOrVersion string) (int, error) {
+	for i, cs := range signatures {
+		if cs.Hash == hashOrVersion || (len(hashOrVersion) >= 7 && strings.HasPrefix(cs.Hash, hashOrVersion)) {
+			return i, nil
+		}
+	}
+
+	if v, err := ParseVersion(hashOrVersion); err == nil {
+		for i, cs := range signatures {
+			if cs.Version == v {
+				return i, nil
+			}
+		}
+	}
+
+	return -1, fmt.Errorf("'%s' is not the hash or version of a commit in the history", hashOrVersion)
+}
+
+// lastAnalysed returns the last commit which could be type checked, or nil if none of them could.
+func lastAnalysed(signatures []*CommitSignature) *CommitSignature {
+	for i := len(signatures) - 1; i >= 0; i-- {
+		if signatures[i].typeChecked() {
+			return signatures[i]
+		}
+	}
+
+	return nil
+}
+
+// packageChanges returns the items which were added or removed, followed by the changes to items.
+func packageChanges(pd diff.PackageDiff) []diff.Change {
+	return append(append([]diff.Change{}, pd.Items...), pd.Changes...)
+}
+
+func changeKey(pkg string, c diff.Change) string {
+	return pkg + " " + c.Element + " " + c.Name
+}
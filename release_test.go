@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/a-h/ver/diff"
+	"github.com/a-h/ver/git"
+	"github.com/a-h/ver/signature"
+)
+
+func testHistory() []*CommitSignature {
+	return []*CommitSignature{
+		{
+			Commit:    git.Commit{Hash: "aaaaaaa1", Subject: "First-commit", Title: "First commit", Name: "Name A"},
+			Signature: signature.PackageSignatures{"a": signature.Signature{Functions: []string{"func a.Old(s string)"}}},
+			Version:   Version{0, 0, 0},
+		},
+		{
+			Commit:    git.Commit{Hash: "bbbbbbb2", Subject: "Added-New", Title: "Added New", Name: "Name B"},
+			Signature: signature.PackageSignatures{"a": signature.Signature{Functions: []string{"func a.Old(s string)", "func a.New() error"}}},
+			Version:   Version{0, 1, 1},
+		},
+		{
+			Commit: git.Commit{Hash: "ccccccc3", Subject: "Broken", Title: "Broken", Name: "Name C"},
+			Error:  &CommitError{Category: TypeCheckFailed},
+			// The approximate signature of a commit which couldn't be type checked.
+			Signature: signature.PackageSignatures{"a": signature.Signature{Functions: []string{"func a.New() error"}}},
+			Version:   Version{0, 1, 2},
+		},
+		{
+			Commit:    git.Commit{Hash: "ddddddd4", Subject: "Removed-Old", Title: "Removed Old", Name: "Name D", Timestamp: 1481989677},
+			Signature: signature.PackageSignatures{"a": signature.Signature{Functions: []string{"func a.New() error"}}},
+			Version:   Version{1, 1, 3},
+		},
+	}
+}
+
+func TestThatTheChangesInAReleaseAreAttributedToCommits(t *testing.T) {
+	r, err := newRelease(testHistory(), "", "")
+
+	if err != nil {
+		t.Fatalf("failed to calculate the release: %v", err)
+	}
+
+	if r.Version != (Version{1, 1, 3}) || r.Previous != (Version{0, 0, 0}) || r.Commit.Hash != "ddddddd4" {
+		t.Errorf("unexpected release %v, previous %v at commit %s", r.Version, r.Previous, r.Commit.Hash)
+	}
+
+	if len(r.Commits) != 3 {
+		t.Errorf("expected 3 commits in the release, but got %v", r.Commits)
+	}
+
+	if len(r.Changes) != 2 {
+		t.Fatalf("expected 2 changes, but got %v", r.Changes)
+	}
+
+	added, removed := r.Changes[0], r.Changes[1]
+
+	if added.Kind != diff.Added || added.Name != "New" || len(added.Commits) != 1 || added.Commits[0].Hash != "bbbbbbb2" {
+		t.Errorf("expected New to be added by commit bbbbbbb2, but got %v", added)
+	}
+
+	if removed.Kind != diff.Removed || removed.Name != "Old" || len(removed.Commits) != 1 || removed.Commits[0].Hash != "ddddddd4" {
+		t.Errorf("expected Old to be removed by commit ddddddd4, but got %v", removed)
+	}
+}
+
+func TestThatReleasesCanBeSelectedByHashOrVersion(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		expected int
+		err      bool
+	}{
+		{from: "bbbbbbb", to: "1.1.3", expected: 1},
+		{from: "v0.0.0", to: "0.1.1", expected: 1},
+		{from: "ddddddd4", to: "aaaaaaa1", err: true},
+		{from: "2.0.0", err: true},
+	}
+
+	for _, tt := range tests {
+		r, err := newRelease(testHistory(), tt.from, tt.to)
+
+		if tt.err != (err != nil) {
+			t.Errorf("from %s to %s: expected error %v, but got %v", tt.from, tt.to, tt.err, err)
+			continue
+		}
+
+		if !tt.err && len(r.Changes) != tt.expected {
+			t.Errorf("from %s to %s: expected %d changes, but got %v", tt.from, tt.to, tt.expected, r.Changes)
+		}
+	}
+}
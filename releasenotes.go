@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// releaseNotesFuncs are the functions which can be used in release notes templates.
+var releaseNotesFuncs = template.FuncMap{
+	"shortHash": shortHash,
+}
+
+// releaseNotes renders a text/template with the release between two commits of a history
+// written with the -o and -s flags.
+func releaseNotes(args []string) error {
+	flags := flag.NewFlagSet("release-notes", flag.ContinueOnError)
+	in := flags.String("i", "", "The JSON history to read, written by ver with the -o and -s flags.")
+	templateFile := flags.String("t", "", "The text/template file to render.")
+	out := flags.String("o", "", "The file to write the release notes to. Defaults to stdout.")
+	from := flags.String("from", "", "The hash or version of the commit before the release. Defaults to the first commit.")
+	to := flags.String("to", "", "The hash or version of the last commit in the release. Defaults to the last commit.")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *in == "" || *templateFile == "" {
+		return fmt.Errorf("please provide an input history with the -i parameter and a template with the -t parameter")
+	}
+
+	tmpl, err := template.New(filepath.Base(*templateFile)).Funcs(releaseNotesFuncs).ParseFiles(*templateFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the template: %v", err)
+	}
+
+	inFile, err := os.Open(*in)
+	if err != nil {
+		return fmt.Errorf("failed to open input history: %v", err)
+	}
+	defer inFile.Close()
+
+	signatures, err := readHistory(inFile)
+	if err != nil {
+		return err
+	}
+
+	r, err := newRelease(signatures, *from, *to)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return writeReleaseNotes(os.Stdout, tmpl, r)
+	}
+
+	outFile, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %v", err)
+	}
+	defer outFile.Close()
+
+	return writeReleaseNotes(outFile, tmpl, r)
+}
+
+// writeReleaseNotes renders the template with the release.
+func writeReleaseNotes(w io.Writer, tmpl *template.Template, r release) error {
+	if err := tmpl.Execute(w, r); err != nil {
+		return fmt.Errorf("failed to render the release notes: %v", err)
+	}
+
+	return nil
+}
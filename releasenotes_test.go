@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+)
+
+func TestThatReleaseNotesCanBeRenderedFromATemplate(t *testing.T) {
+	r, err := newRelease(testHistory(), "", "")
+
+	if err != nil {
+		t.Fatalf("failed to calculate the release: %v", err)
+	}
+
+	tmpl := template.Must(template.New("notes").Funcs(releaseNotesFuncs).Parse(
+		`{{ .Previous }} -> {{ .Version }}
+{{ range .Commits }}{{ shortHash .Hash }} {{ .Title }} by {{ .Name }}
+{{ end }}{{ range .Breaking }}{{ .Package }}: {{ .Name }} {{ .Kind }} ({{ .Impact }})
+{{ end }}{{ len .Diff.Packages }} package(s) changed`))
+
+	buf := new(bytes.Buffer)
+	if err = writeReleaseNotes(buf, tmpl, r); err != nil {
+		t.Fatalf("failed to write the release notes: %v", err)
+	}
+
+	expected := `0.0.0 -> 1.1.3
+bbbbbbb Added New by Name B
+ccccccc Broken by Name C
+ddddddd Removed Old by Name D
+a: Old removed (breaking)
+1 package(s) changed`
+
+	if actual := buf.String(); actual != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, actual)
+	}
+}
+
+func TestThatTemplateErrorsAreReturned(t *testing.T) {
+	tmpl := template.Must(template.New("notes").Parse(`{{ .Missing }}`))
+
+	if err := writeReleaseNotes(new(bytes.Buffer), tmpl, release{}); err == nil {
+		t.Error("expected an error rendering a field which doesn't exist")
+	}
+}
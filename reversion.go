@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// reversion recalculates the versions of a history written with the -o and -s flags,
+// using a different policy or starting version, without cloning the repository again.
+func reversion(args []string) error {
+	flags := flag.NewFlagSet("reversion", flag.ContinueOnError)
+	in := flags.String("i", "", "The JSON history to read, written by ver with the -o and -s flags.")
+	out := flags.String("o", "", "The file to write the recalculated JSON history to.")
+	policyName := flags.String("policy", "default", "The name of a built-in policy, or the path to a JSON policy file.")
+	startVersion := flags.String("start", "0.0.0", "The version of the first commit.")
+	includeSignatures := flags.Bool("s", false, "When set, includes the signature of each commit in the JSON output.")
+	includeDiffs := flags.Bool("d", false, "When set, includes the diff against the previous analysed commit in the JSON output.")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *in == "" || *out == "" {
+		return fmt.Errorf("please provide an input history with the -i parameter and an output file with the -o parameter")
+	}
+
+	policy, err := loadPolicy(*policyName)
+	if err != nil {
+		return err
+	}
+
+	start, err := ParseVersion(*startVersion)
+	if err != nil {
+		return err
+	}
+
+	inFile, err := os.Open(*in)
+	if err != nil {
+		return fmt.Errorf("failed to open input history: %v", err)
+	}
+	defer inFile.Close()
+
+	signatures, err := readHistory(inFile)
+	if err != nil {
+		return err
+	}
+
+	if err = checkHistoryHasSignatures(signatures); err != nil {
+		return err
+	}
+
+	packageName := ""
+	if len(signatures) > 0 {
+		packageName = signatures[0].Package
+	}
+
+	addPackageNameAndVersionToSignatures(signatures, packageName, start, policy)
+
+	outFile, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %v", err)
+	}
+	defer outFile.Close()
+
+	if err = writeHistory(outFile, signatures, *includeSignatures, *includeDiffs); err != nil {
+		return err
+	}
+
+	if len(signatures) > 0 {
+		fmt.Printf("Recalculated %d commits, the latest version is %v\n", len(signatures), signatures[len(signatures)-1].Version)
+	}
+
+	return nil
+}
+
+// checkHistoryHasSignatures returns an error if none of the successfully analysed commits
+// include a signature, which happens when the history was written without the -s flag.
+func checkHistoryHasSignatures(signatures []*CommitSignature) error {
+	analysed := 0
+
+	for _, cs := range signatures {
+		if cs.Error != nil {
+			continue
+		}
+
+		if cs.Signature != nil {
+			return nil
+		}
+
+		analysed++
+	}
+
+	if analysed == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("the history does not include signatures, please write it with the -s flag")
+}
@@ -0,0 +1,575 @@
+package signature
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// GetApproximateFromDirectory gets the signature of a directory of Go files, including subdirectories,
+// using only the parser. It's used when the code can't be type checked, e.g. because a dependency is
+// missing or the code doesn't compile. Types are recorded as they're written in the source, method
+// sets don't include promoted methods, and unexported types which are reachable from exported
+// declarations and the positions of items aren't included, so each Signature is marked as Approximate.
+func GetApproximateFromDirectory(gopath string, dir string, opts Options) (PackageSignatures, error) {
+	directories, err := walkDirectories(dir, opts)
+
+	if err != nil {
+		return PackageSignatures{}, err
+	}
+
+	rv := PackageSignatures{}
+	found := false
+
+	for _, d := range directories {
+		filenames, err := getFiles(dir, d, opts)
+
+		if err != nil {
+			return PackageSignatures{}, err
+		}
+
+		fset := token.NewFileSet()
+		files := []*ast.File{}
+
+		for _, filename := range filenames {
+			// Keep whatever could be parsed, even if the file contains syntax errors.
+			f, _ := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+
+			if f != nil {
+				files = append(files, f)
+			}
+		}
+
+		if len(files) == 0 {
+			continue
+		}
+
+		found = true
+		pkg := importPath(gopath, d)
+
+		if !opts.includePackage(importPath(gopath, dir), pkg, files[0].Name.Name) {
+			continue
+		}
+
+		rv[pkg] = GetApproximateFromFiles(pkg, files)
+	}
+
+	if !found {
+		return rv, ErrNoGoFiles
+	}
+
+	return rv, nil
+}
+
+// GetApproximateFromFiles gets an approximate Signature from the parsed files of a package.
+func GetApproximateFromFiles(pkg string, files []*ast.File) Signature {
+	rv := NewSignature()
+	rv.Approximate = true
+
+	for _, f := range files {
+		a := approximator{
+			pkg:     pkg,
+			imports: fileImports(f),
+		}
+
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				a.addFunc(&rv, d)
+			case *ast.GenDecl:
+				a.addGenDecl(&rv, d)
+			}
+		}
+	}
+
+	addDeprecated(&rv, files)
+	addIotaBlocks(&rv, files)
+
+	return rv.sorted()
+}
+
+// approximator renders declarations in the same format as the type checked signature.
+type approximator struct {
+	pkg string
+	// imports maps the names of the imports of a file to their paths.
+	imports map[string]string
+}
+
+func fileImports(f *ast.File) map[string]string {
+	imports := map[string]string{}
+
+	for _, is := range f.Imports {
+		p, err := strconv.Unquote(is.Path.Value)
+
+		if err != nil {
+			continue
+		}
+
+		name := guessPackageName(p)
+
+		if is.Name != nil {
+			name = is.Name.Name
+		}
+
+		imports[name] = p
+	}
+
+	return imports
+}
+
+// guessPackageName guesses the name of a package from its import path, since the
+// imported package can't be read, e.g. "gopkg.in/yaml.v2" is assumed to be "yaml".
+func guessPackageName(importPath string) string {
+	name := path.Base(importPath)
+
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+
+	name = strings.TrimPrefix(name, "go-")
+
+	return strings.Replace(name, "-", "_", -1)
+}
+
+func (a approximator) addFunc(rv *Signature, d *ast.FuncDecl) {
+	if !d.Name.IsExported() {
+		return
+	}
+
+	signature := a.signature(d.Type.Params, d.Type.Results)
+
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		rv.Functions = append(rv.Functions, "func "+a.pkg+"."+d.Name.Name+signature)
+		return
+	}
+
+	recv := d.Recv.List[0].Type
+	pointer := false
+
+	if se, ok := recv.(*ast.StarExpr); ok {
+		recv = se.X
+		pointer = true
+	}
+
+	name := receiverName(recv)
+
+	if !ast.IsExported(name) {
+		return
+	}
+
+	ms := rv.MethodSets[name]
+
+	// Value receiver methods are in the method set of both the type and a pointer to the type.
+	if !pointer {
+		rv.Functions = append(rv.Functions, "method ("+a.pkg+"."+name+") "+d.Name.Name+signature)
+		ms.Value = append(ms.Value, d.Name.Name)
+	}
+
+	rv.Functions = append(rv.Functions, "method (*"+a.pkg+"."+name+") "+d.Name.Name+signature)
+	ms.Pointer = append(ms.Pointer, d.Name.Name)
+	rv.setMethodSet(name, ms)
+}
+
+func receiverName(recv ast.Expr) string {
+	switch r := recv.(type) {
+	case *ast.Ident:
+		return r.Name
+	case *ast.IndexExpr:
+		return receiverName(r.X)
+	case *ast.IndexListExpr:
+		return receiverName(r.X)
+	case *ast.ParenExpr:
+		return receiverName(r.X)
+	}
+
+	return ""
+}
+
+func (a approximator) addGenDecl(rv *Signature, d *ast.GenDecl) {
+	// Constants without a value repeat the type and value of the previous constant.
+	var previousType ast.Expr
+	var previousValues []ast.Expr
+
+	for _, spec := range d.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			a.addType(rv, s)
+		case *ast.ValueSpec:
+			if d.Tok == token.CONST && len(s.Values) == 0 {
+				s = &ast.ValueSpec{Names: s.Names, Type: previousType, Values: previousValues}
+			}
+
+			previousType, previousValues = s.Type, s.Values
+
+			for i, name := range s.Names {
+				if !name.IsExported() {
+					continue
+				}
+
+				var value ast.Expr
+
+				if i < len(s.Values) {
+					value = s.Values[i]
+				}
+
+				if d.Tok == token.CONST {
+					rv.Constants = append(rv.Constants, a.constant(name.Name, s.Type, value))
+					continue
+				}
+
+				rv.Fields = append(rv.Fields, a.variable(name.Name, s.Type, value))
+			}
+		}
+	}
+}
+
+func (a approximator) addType(rv *Signature, s *ast.TypeSpec) {
+	if !s.Name.IsExported() {
+		return
+	}
+
+	if s.Assign.IsValid() {
+		rv.Types = append(rv.Types, "type "+a.pkg+"."+s.Name.Name+" = "+a.typeString(s.Type))
+		return
+	}
+
+	if _, isInterface := s.Type.(*ast.InterfaceType); !isInterface {
+		rv.setMethodSet(s.Name.Name, rv.MethodSets[s.Name.Name])
+	}
+
+	switch t := s.Type.(type) {
+	case *ast.StructType:
+		rv.Structs = append(rv.Structs, a.renderStruct(s.Name.Name, t))
+		if rv.StructDetails == nil {
+			rv.StructDetails = map[string]Struct{}
+		}
+		rv.StructDetails[s.Name.Name] = a.newStruct(t)
+	case *ast.InterfaceType:
+		rv.Interfaces = append(rv.Interfaces, a.pkg+"."+s.Name.Name)
+
+		for _, m := range t.Methods.List {
+			ft, ok := m.Type.(*ast.FuncType)
+
+			if !ok {
+				// Embedded interfaces can't be expanded without the type checker.
+				continue
+			}
+
+			for _, name := range m.Names {
+				if name.IsExported() {
+					rv.Functions = append(rv.Functions, "method ("+a.pkg+"."+s.Name.Name+") "+name.Name+a.signature(ft.Params, ft.Results))
+				}
+			}
+		}
+	default:
+		rv.Types = append(rv.Types, "type "+a.pkg+"."+s.Name.Name+" "+a.typeString(s.Type))
+	}
+}
+
+func (a approximator) constant(name string, typ ast.Expr, value ast.Expr) string {
+	t := "untyped"
+
+	if typ != nil {
+		t = a.typeString(typ)
+	} else if bl, ok := value.(*ast.BasicLit); ok {
+		t = "untyped " + basicLitType(bl)
+	}
+
+	v := "?"
+
+	if value != nil {
+		v = types.ExprString(value)
+	}
+
+	return "const " + a.pkg + "." + name + " " + t + " = " + v
+}
+
+func (a approximator) variable(name string, typ ast.Expr, value ast.Expr) string {
+	rv := "var " + a.pkg + "." + name
+
+	if typ == nil {
+		typ = guessType(value)
+	}
+
+	if typ != nil {
+		rv += " " + a.typeString(typ)
+	}
+
+	return rv
+}
+
+// guessType guesses the type of a variable declared without one, e.g. var ErrNotFound = errors.New("not found").
+func guessType(value ast.Expr) ast.Expr {
+	switch v := value.(type) {
+	case *ast.BasicLit:
+		return ast.NewIdent(defaultType(v))
+	case *ast.CompositeLit:
+		return v.Type
+	case *ast.UnaryExpr:
+		if cl, ok := v.X.(*ast.CompositeLit); ok && v.Op == token.AND && cl.Type != nil {
+			return &ast.StarExpr{X: cl.Type}
+		}
+	case *ast.CallExpr:
+		switch types.ExprString(v.Fun) {
+		case "errors.New", "fmt.Errorf":
+			return ast.NewIdent("error")
+		}
+	}
+
+	return nil
+}
+
+func basicLitType(bl *ast.BasicLit) string {
+	switch bl.Kind {
+	case token.INT:
+		return "int"
+	case token.FLOAT:
+		return "float"
+	case token.IMAG:
+		return "complex"
+	case token.CHAR:
+		return "rune"
+	}
+
+	return "string"
+}
+
+func defaultType(bl *ast.BasicLit) string {
+	switch bl.Kind {
+	case token.FLOAT:
+		return "float64"
+	case token.IMAG:
+		return "complex128"
+	case token.CHAR:
+		return "rune"
+	}
+
+	return basicLitType(bl)
+}
+
+func (a approximator) renderStruct(name string, s *ast.StructType) string {
+	rendered := []string{}
+	fields, names := structFields(s)
+
+	for fi, f := range fields {
+		if !ast.IsExported(names[fi]) {
+			continue
+		}
+
+		var field string
+
+		if st, isStruct := f.Type.(*ast.StructType); isStruct {
+			field = names[fi] + " " + a.renderStruct("", st)
+		} else {
+			field = "field " + names[fi] + " " + a.typeString(f.Type)
+		}
+
+		rendered = append(rendered, field+renderTag(tag(f)))
+	}
+
+	return joinStruct(name, rendered)
+}
+
+func (a approximator) newStruct(s *ast.StructType) Struct {
+	rv := Struct{
+		Comparable: comparable(s),
+	}
+	fields, names := structFields(s)
+
+	for fi, f := range fields {
+		if !ast.IsExported(names[fi]) {
+			rv.Unexported = true
+			continue
+		}
+
+		field := Field{
+			Name: names[fi],
+			Type: a.typeString(f.Type),
+			Tag:  tag(f),
+		}
+
+		if st, isStruct := f.Type.(*ast.StructType); isStruct {
+			field.Type = a.renderStruct("", st)
+		}
+
+		rv.Fields = append(rv.Fields, field)
+	}
+
+	return rv
+}
+
+// comparable guesses whether values of a type can be compared with ==. Named types are assumed
+// to be comparable, since their definition isn't known without the type checker.
+func comparable(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.ParenExpr:
+		return comparable(t.X)
+	case *ast.ArrayType:
+		// Slices aren't comparable, arrays are if their elements are.
+		return t.Len != nil && comparable(t.Elt)
+	case *ast.MapType, *ast.FuncType:
+		return false
+	case *ast.StructType:
+		for _, f := range t.Fields.List {
+			if !comparable(f.Type) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// structFields returns each field of a struct, along with its name, so that fields declared
+// together, e.g. "A, B string", are listed separately.
+func structFields(s *ast.StructType) (fields []*ast.Field, names []string) {
+	for _, f := range s.Fields.List {
+		if len(f.Names) == 0 {
+			// Embedded fields are named after their type.
+			fields = append(fields, f)
+			names = append(names, embeddedName(f.Type))
+			continue
+		}
+
+		for _, n := range f.Names {
+			fields = append(fields, f)
+			names = append(names, n.Name)
+		}
+	}
+
+	return fields, names
+}
+
+func tag(f *ast.Field) string {
+	if f.Tag == nil {
+		return ""
+	}
+
+	t, err := strconv.Unquote(f.Tag.Value)
+
+	if err != nil {
+		return ""
+	}
+
+	return t
+}
+
+func embeddedName(t ast.Expr) string {
+	switch e := t.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	}
+
+	return receiverName(t)
+}
+
+// signature renders parameters and results in the format used by types.ObjectString.
+func (a approximator) signature(params *ast.FieldList, results *ast.FieldList) string {
+	rv := "(" + a.fieldList(params) + ")"
+
+	if results == nil || len(results.List) == 0 {
+		return rv
+	}
+
+	if len(results.List) == 1 && len(results.List[0].Names) == 0 {
+		return rv + " " + a.typeString(results.List[0].Type)
+	}
+
+	return rv + " (" + a.fieldList(results) + ")"
+}
+
+func (a approximator) fieldList(fl *ast.FieldList) string {
+	if fl == nil {
+		return ""
+	}
+
+	items := []string{}
+
+	for _, f := range fl.List {
+		t := a.typeString(f.Type)
+
+		if len(f.Names) == 0 {
+			items = append(items, t)
+			continue
+		}
+
+		for _, n := range f.Names {
+			items = append(items, n.Name+" "+t)
+		}
+	}
+
+	return strings.Join(items, ", ")
+}
+
+// typeString renders a type expression, qualifying names with their package path.
+func (a approximator) typeString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if _, predeclared := types.Universe.Lookup(e.Name).(*types.TypeName); predeclared {
+			return e.Name
+		}
+		return a.pkg + "." + e.Name
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			if p, ok := a.imports[x.Name]; ok {
+				return p + "." + e.Sel.Name
+			}
+		}
+	case *ast.StarExpr:
+		return "*" + a.typeString(e.X)
+	case *ast.ParenExpr:
+		return a.typeString(e.X)
+	case *ast.Ellipsis:
+		return "..." + a.typeString(e.Elt)
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return "[]" + a.typeString(e.Elt)
+		}
+		return "[" + types.ExprString(e.Len) + "]" + a.typeString(e.Elt)
+	case *ast.MapType:
+		return "map[" + a.typeString(e.Key) + "]" + a.typeString(e.Value)
+	case *ast.ChanType:
+		switch e.Dir {
+		case ast.SEND:
+			return "chan<- " + a.typeString(e.Value)
+		case ast.RECV:
+			return "<-chan " + a.typeString(e.Value)
+		}
+		return "chan " + a.typeString(e.Value)
+	case *ast.FuncType:
+		return "func" + a.signature(e.Params, e.Results)
+	case *ast.StructType:
+		fields := []string{}
+		for _, f := range e.Fields.List {
+			if len(f.Names) == 0 {
+				fields = append(fields, a.typeString(f.Type))
+				continue
+			}
+			for _, n := range f.Names {
+				fields = append(fields, n.Name+" "+a.typeString(f.Type))
+			}
+		}
+		return "struct{" + strings.Join(fields, "; ") + "}"
+	case *ast.InterfaceType:
+		methods := []string{}
+		for _, m := range e.Methods.List {
+			ft, ok := m.Type.(*ast.FuncType)
+			if !ok {
+				methods = append(methods, a.typeString(m.Type))
+				continue
+			}
+			for _, n := range m.Names {
+				methods = append(methods, n.Name+a.signature(ft.Params, ft.Results))
+			}
+		}
+		return "interface{" + strings.Join(methods, "; ") + "}"
+	}
+
+	return types.ExprString(expr)
+}
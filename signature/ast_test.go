@@ -0,0 +1,182 @@
+package signature
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestThatApproximateSignaturesMatchTypeCheckedSignatures(t *testing.T) {
+	basePackage := "github.com/a-h/nonexistent"
+
+	tests := []struct {
+		name string
+		code []string
+	}{
+		{
+			name: "Functions",
+			code: []string{"package nonexistent", "func A(a, b string, c ...int) (int, error) { return 0, nil }", "func b() {}"},
+		},
+		{
+			name: "Functions with named results and function parameters",
+			code: []string{"package nonexistent", "func A(f func(int) bool, c <-chan int) (n int, err error) { return }"},
+		},
+		{
+			name: "Receiver methods",
+			code: []string{"package nonexistent", "type Test struct { value string }",
+				"func (t Test) A() string { return t.value }", "func (t *Test) B(m map[string][]*Test) {}", "type Mode int", "func (m *Mode) Set() {}"},
+		},
+		{
+			name: "Structs",
+			code: []string{"package nonexistent", "type Test struct { A, b string; C int; D struct{ E string } }"},
+		},
+		{
+			name: "Struct comparability",
+			code: []string{"package nonexistent", "type A struct { B string; c []string }", "type D struct { E [2]int; F struct{ G map[string]int } }",
+				"type H struct { I [3]string; j func() }", "type K struct { L *K; m chan int }"},
+		},
+		{
+			name: "Structs with tags",
+			code: []string{"package nonexistent", "type Test struct { A string `json:\"a\"`; B, C int \"b,c\"; D struct{ E string `json:\"e\"` } }"},
+		},
+		{
+			name: "Interfaces",
+			code: []string{"package nonexistent", "type Test interface { Close() error }"},
+		},
+		{
+			name: "Fields",
+			code: []string{"package nonexistent", "var A []string", "var B = 1", "var C = &Test{}", "type Test struct {}"},
+		},
+		{
+			name: "Constants",
+			code: []string{"package nonexistent", "const A = 1", "const B = \"b\"", "const C int = 3"},
+		},
+		{
+			name: "Types and aliases",
+			code: []string{"package nonexistent", "type Mode int", "type Names []string", "type Alias = Mode", "type Number = int"},
+		},
+	}
+
+	for _, tt := range tests {
+		code := strings.Join(tt.code, "\n")
+
+		pkg, err := parseGoIntoPackage(basePackage, code)
+
+		if err != nil {
+			t.Errorf("%s - failed to parse Go with error %v", tt.name, err)
+			continue
+		}
+
+		expected := GetFromScope(pkg.Scope())
+
+		f, err := parser.ParseFile(token.NewFileSet(), "test.go", code, 0)
+
+		if err != nil {
+			t.Errorf("%s - failed to parse Go with error %v", tt.name, err)
+			continue
+		}
+
+		actual := GetApproximateFromFiles(basePackage, []*ast.File{f})
+
+		if !actual.Approximate {
+			t.Errorf("%s - expected the signature to be marked as approximate", tt.name)
+		}
+
+		compareSets(tt.name, "Functions", expected.Functions, actual.Functions, t)
+		compareSets(tt.name, "Fields", expected.Fields, actual.Fields, t)
+		compareSets(tt.name, "Constants", expected.Constants, actual.Constants, t)
+		compareSets(tt.name, "Structs", expected.Structs, actual.Structs, t)
+		compareSets(tt.name, "Interfaces", expected.Interfaces, actual.Interfaces, t)
+		compareSets(tt.name, "Types", expected.Types, actual.Types, t)
+
+		if len(expected.MethodSets) != len(actual.MethodSets) {
+			t.Errorf("%s - expected method sets %v, but got %v", tt.name, expected.MethodSets, actual.MethodSets)
+		}
+
+		for name, ms := range expected.MethodSets {
+			compareSets(tt.name, name+" value methods", ms.Value, actual.MethodSets[name].Value, t)
+			compareSets(tt.name, name+" pointer methods", ms.Pointer, actual.MethodSets[name].Pointer, t)
+		}
+
+		if !reflect.DeepEqual(expected.StructDetails, actual.StructDetails) {
+			t.Errorf("%s - expected struct details %v, but got %v", tt.name, expected.StructDetails, actual.StructDetails)
+		}
+	}
+}
+
+func TestThatApproximateSignaturesCanBeReadFromCodeWhichDoesNotCompile(t *testing.T) {
+	code := strings.Join([]string{
+		"package nonexistent",
+		"import \"github.com/a-h/missing\"",
+		"func A(m missing.Type) string { return undefined }",
+	}, "\n")
+
+	f, err := parser.ParseFile(token.NewFileSet(), "test.go", code, 0)
+
+	if err != nil {
+		t.Fatalf("failed to parse Go with error %v", err)
+	}
+
+	actual := GetApproximateFromFiles("github.com/a-h/nonexistent", []*ast.File{f})
+
+	expected := []string{"func github.com/a-h/nonexistent.A(m github.com/a-h/missing.Type) string"}
+	compareSets("Code which does not compile", "Functions", expected, actual.Functions, t)
+}
+
+func TestThatApproximateSignaturesAreSorted(t *testing.T) {
+	code := strings.Join([]string{
+		"package nonexistent",
+		"func B() {}",
+		"func A() {}",
+		"type T struct{}",
+		"func (t T) M() {}",
+		"func (t *T) N() {}",
+	}, "\n")
+
+	f, err := parser.ParseFile(token.NewFileSet(), "test.go", code, 0)
+
+	if err != nil {
+		t.Fatalf("failed to parse Go with error %v", err)
+	}
+
+	actual := GetApproximateFromFiles("github.com/a-h/nonexistent", []*ast.File{f})
+
+	expected := []string{
+		"func github.com/a-h/nonexistent.A()",
+		"func github.com/a-h/nonexistent.B()",
+		"method (*github.com/a-h/nonexistent.T) M()",
+		"method (*github.com/a-h/nonexistent.T) N()",
+		"method (github.com/a-h/nonexistent.T) M()",
+	}
+
+	if !reflect.DeepEqual(actual.Functions, expected) {
+		t.Errorf("expected %v, but got %v", expected, actual.Functions)
+	}
+}
+
+func compareSets(testname string, element string, expected []string, actual []string, t *testing.T) {
+	e := map[string]bool{}
+	for _, v := range expected {
+		e[v] = true
+	}
+
+	a := map[string]bool{}
+	for _, v := range actual {
+		a[v] = true
+	}
+
+	for v := range e {
+		if !a[v] {
+			t.Errorf("%s - %s - expected '%s', but it was missing from %v", testname, element, v, actual)
+		}
+	}
+
+	for v := range a {
+		if !e[v] {
+			t.Errorf("%s - %s - did not expect '%s'", testname, element, v)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package signature
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// addDeprecated records the exported items in the files which have a "Deprecated: " paragraph
+// in their doc comment. The files must be parsed with comments.
+func addDeprecated(sig *Signature, files []*ast.File) {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Name.IsExported() {
+					sig.setDeprecated(funcDeclName(d), d.Doc)
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					doc := specDoc(spec)
+
+					// A doc comment on the declaration applies to it, if it only has one spec.
+					if doc == nil && len(d.Specs) == 1 {
+						doc = d.Doc
+					}
+
+					for _, name := range specNames(spec) {
+						if name.IsExported() {
+							sig.setDeprecated(name.Name, doc)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// setDeprecated records the name as deprecated if the doc comment says it is.
+func (s *Signature) setDeprecated(name string, doc *ast.CommentGroup) {
+	message, ok := deprecation(doc)
+
+	if !ok {
+		return
+	}
+
+	if s.Deprecated == nil {
+		s.Deprecated = map[string]string{}
+	}
+
+	s.Deprecated[name] = message
+}
+
+// deprecation returns the text of the "Deprecated: " paragraph of the doc comment.
+func deprecation(doc *ast.CommentGroup) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+
+	for _, paragraph := range strings.Split(doc.Text(), "\n\n") {
+		if strings.HasPrefix(paragraph, "Deprecated: ") {
+			return strings.Join(strings.Fields(strings.TrimPrefix(paragraph, "Deprecated: ")), " "), true
+		}
+	}
+
+	return "", false
+}
+
+// funcDeclName returns the name of a function, e.g. "Clone", or a method, e.g. "(*Git).Log".
+func funcDeclName(d *ast.FuncDecl) string {
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		return d.Name.Name
+	}
+
+	recv := d.Recv.List[0].Type
+	pointer := ""
+
+	if star, ok := recv.(*ast.StarExpr); ok {
+		pointer, recv = "*", star.X
+	}
+
+	// Remove the type parameters of generic receivers.
+	switch r := recv.(type) {
+	case *ast.IndexExpr:
+		recv = r.X
+	case *ast.IndexListExpr:
+		recv = r.X
+	}
+
+	return "(" + pointer + types.ExprString(recv) + ")." + d.Name.Name
+}
+
+func specDoc(spec ast.Spec) *ast.CommentGroup {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		return s.Doc
+	case *ast.ValueSpec:
+		return s.Doc
+	}
+
+	return nil
+}
+
+func specNames(spec ast.Spec) []*ast.Ident {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		return []*ast.Ident{s.Name}
+	case *ast.ValueSpec:
+		return s.Names
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package signature
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+func TestThatDeprecatedItemsAreRecorded(t *testing.T) {
+	code := `package a
+
+// Old does something.
+//
+// Deprecated: use New instead,
+// which is faster.
+func Old() {}
+
+// New does something.
+func New() {}
+
+// Deprecated: use Client.Get.
+type Getter interface{}
+
+type Client struct{}
+
+// Deprecated: use Get.
+func (c *Client) Fetch() {}
+
+// Deprecated: the values are now typed.
+const (
+	A = 1
+	// Deprecated: use A.
+	B = 2
+	C = 3
+)
+
+// Deprecated: unexported items aren't part of the API.
+func private() {}
+`
+
+	f, err := parser.ParseFile(token.NewFileSet(), "a.go", code, parser.ParseComments)
+
+	if err != nil {
+		t.Fatalf("failed to parse Go with error %v", err)
+	}
+
+	sig := NewSignature()
+	addDeprecated(&sig, []*ast.File{f})
+
+	expected := map[string]string{
+		"Old":             "use New instead, which is faster.",
+		"Getter":          "use Client.Get.",
+		"(*Client).Fetch": "use Get.",
+		"B":               "use A.",
+	}
+
+	if !reflect.DeepEqual(sig.Deprecated, expected) {
+		t.Errorf("expected %v, but got %v", expected, sig.Deprecated)
+	}
+}
@@ -0,0 +1,69 @@
+package signature
+
+import (
+	"errors"
+	"go/scanner"
+	"go/types"
+)
+
+// ErrNoGoFiles is returned when a directory and its subdirectories don't contain any Go files.
+var ErrNoGoFiles = errors.New("no Go files found")
+
+// LoadError is returned when the Go files can't be parsed or type checked.
+type LoadError struct {
+	Err error
+	// TypeErrors are the errors reported by the parser and type checker.
+	TypeErrors []TypeError
+}
+
+func (e LoadError) Error() string {
+	return e.Err.Error()
+}
+
+// TypeError is an error reported by the parser or type checker.
+type TypeError struct {
+	Position
+	Message string `json:"message"`
+}
+
+// newTypeErrors converts errors reported by the parser and type checker, making
+// their positions relative to the directory being analysed.
+func newTypeErrors(dir string, err error) []TypeError {
+	switch e := err.(type) {
+	case types.Error:
+		p := e.Fset.Position(e.Pos)
+		return []TypeError{
+			{
+				Position: errorPosition(dir, p.Filename, p.Line, p.Column),
+				Message:  e.Msg,
+			},
+		}
+	case scanner.ErrorList:
+		rv := []TypeError{}
+		for _, se := range e {
+			rv = append(rv, newTypeErrors(dir, *se)...)
+		}
+		return rv
+	case scanner.Error:
+		return []TypeError{
+			{
+				Position: errorPosition(dir, e.Pos.Filename, e.Pos.Line, e.Pos.Column),
+				Message:  e.Msg,
+			},
+		}
+	}
+
+	return []TypeError{{Message: err.Error()}}
+}
+
+// errorPosition returns the position of an error relative to dir. Errors in files outside of dir,
+// e.g. in a dependency, don't have a position, since it would be a path on the machine running ver.
+func errorPosition(dir string, filename string, line int, column int) Position {
+	file, ok := relativeFile(dir, filename)
+
+	if !ok {
+		return Position{}
+	}
+
+	return Position{File: file, Line: line, Column: column}
+}
@@ -0,0 +1,85 @@
+package signature
+
+import (
+	"go/scanner"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestThatTypeErrorsIncludeTheirPosition(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ver_errors")
+
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	code := "package broken\n\nfunc A() string { return x }\n"
+
+	if err = ioutil.WriteFile(path.Join(dir, "a.go"), []byte(code), 0644); err != nil {
+		t.Fatalf("failed to write code: %v", err)
+	}
+
+	_, err = GetFromDirectory(os.Getenv("GOPATH"), dir)
+
+	le, ok := err.(LoadError)
+
+	if !ok {
+		t.Fatalf("expected a LoadError, but got %v", err)
+	}
+
+	if len(le.TypeErrors) != 1 {
+		t.Fatalf("expected 1 type error, but got %v", le.TypeErrors)
+	}
+
+	expected := Position{File: "a.go", Line: 3, Column: 26}
+
+	if le.TypeErrors[0].Position != expected {
+		t.Errorf("expected position %v, but got %v", expected, le.TypeErrors[0].Position)
+	}
+}
+
+func TestThatDirectoriesWithoutGoFilesReturnAnError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ver_errors")
+
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if err = ioutil.WriteFile(path.Join(dir, "README.md"), []byte("# Test"), 0644); err != nil {
+		t.Fatalf("failed to write README: %v", err)
+	}
+
+	_, err = GetFromDirectory(os.Getenv("GOPATH"), dir)
+
+	if err != ErrNoGoFiles {
+		t.Errorf("expected ErrNoGoFiles, but got %v", err)
+	}
+}
+
+func TestThatTypeErrorsOutsideTheDirectoryDontHaveAPosition(t *testing.T) {
+	tests := []struct {
+		filename string
+		expected Position
+	}{
+		{filename: "/tmp/ver_history123/src/github.com/a-h/ver/a.go", expected: Position{File: "a.go", Line: 3, Column: 2}},
+		{filename: "/tmp/ver_history123/src/github.com/x/y/y.go", expected: Position{}},
+		{filename: "/usr/lib/go/src/strings/reader.go", expected: Position{}},
+	}
+
+	for _, tt := range tests {
+		err := scanner.Error{Pos: token.Position{Filename: tt.filename, Line: 3, Column: 2}, Msg: "expected ';'"}
+
+		actual := newTypeErrors("/tmp/ver_history123/src/github.com/a-h/ver", err)
+
+		if len(actual) != 1 || actual[0].Position != tt.expected || actual[0].Message != "expected ';'" {
+			t.Errorf("for %s, expected position %v, but got %v", tt.filename, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package signature
+
+import (
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// Func describes the parameters and results of a function or method.
+type Func struct {
+	Params   []Param `json:"params"`
+	Results  []Param `json:"results"`
+	Variadic bool    `json:"variadic,omitempty"`
+}
+
+// Param is a parameter or result of a function.
+type Param struct {
+	Name string `json:"name,omitempty"`
+	// Type is the type of the parameter. The type of a variadic parameter is a slice, e.g. "[]string".
+	Type string `json:"type"`
+	// Interface is true when the type is an interface.
+	Interface bool `json:"interface,omitempty"`
+	// Implements lists the well-known interfaces, and the interfaces of the packages being analysed,
+	// which the type of a parameter implements, e.g. ["io.Reader"] for "*bytes.Buffer". It's used to
+	// find parameters which are widened to an interface.
+	Implements []string `json:"implements,omitempty"`
+}
+
+// newFunc describes the function. The interfaces which the type of each parameter implements
+// are recorded.
+func newFunc(s *types.Signature, interfaces []namedInterface) Func {
+	return Func{
+		Params:   newParams(s.Params(), interfaces),
+		Results:  newParams(s.Results(), nil),
+		Variadic: s.Variadic(),
+	}
+}
+
+func newParams(t *types.Tuple, interfaces []namedInterface) []Param {
+	var rv []Param
+
+	for i := 0; i < t.Len(); i++ {
+		v := t.At(i)
+
+		p := Param{
+			Name:      v.Name(),
+			Type:      types.TypeString(v.Type(), nil),
+			Interface: types.IsInterface(v.Type()),
+		}
+
+		if !p.Interface {
+			p.Implements = implementedBy(v.Type(), interfaces)
+		}
+
+		rv = append(rv, p)
+	}
+
+	return rv
+}
+
+// implementedBy returns the names of the interfaces which the type implements, in order.
+func implementedBy(t types.Type, interfaces []namedInterface) []string {
+	var rv []string
+
+	for _, i := range interfaces {
+		if types.Implements(t, i.iface) && !contains(rv, i.name) {
+			rv = append(rv, i.name)
+		}
+	}
+
+	sort.Strings(rv)
+
+	return rv
+}
+
+// methodName returns the name of a method including its receiver, without the package path, e.g.
+// "(*Git).Log". It's the same name that the diff package uses for items.
+func methodName(recv types.Type, name string) string {
+	return MethodName(types.TypeString(recv, nil), name)
+}
+
+// MethodName returns the name of a method with the receiver, without the package path or type
+// arguments of the receiver, e.g. "(*List).Push" for the receiver "*github.com/a-h/example.List[T]".
+// Details of methods are keyed by this name.
+func MethodName(receiver string, name string) string {
+	pointer := ""
+
+	if strings.HasPrefix(receiver, "*") {
+		pointer = "*"
+		receiver = strings.TrimPrefix(receiver, "*")
+	}
+
+	if i := strings.Index(receiver, "["); i >= 0 {
+		receiver = receiver[:i]
+	}
+
+	return "(" + pointer + receiver[strings.LastIndex(receiver, ".")+1:] + ")." + name
+}
+func (s *Signature) setFunc(name string, f Func) {
+	if s.Funcs == nil {
+		s.Funcs = map[string]Func{}
+	}
+
+	s.Funcs[name] = f
+}
@@ -0,0 +1,220 @@
+package signature
+
+import (
+	"fmt"
+	"go/build"
+	"go/types"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/loader"
+)
+
+// DefaultInterfaces are the well-known interfaces which exported types are checked against
+// when the Interfaces option isn't set.
+var DefaultInterfaces = []string{
+	"error",
+	"fmt.Stringer",
+	"io.Reader",
+	"io.Writer",
+	"io.Closer",
+	"sort.Interface",
+	"encoding.TextMarshaler",
+	"encoding.TextUnmarshaler",
+	"encoding/json.Marshaler",
+	"encoding/json.Unmarshaler",
+}
+
+// interfaces returns the well-known interfaces configured by the options.
+func (o Options) interfaces() []string {
+	if o.Interfaces == nil {
+		return DefaultInterfaces
+	}
+
+	return o.Interfaces
+}
+
+// namedInterface is an interface, along with its qualified name, e.g. "io.Reader".
+type namedInterface struct {
+	name  string
+	iface *types.Interface
+}
+
+// splitInterfaceName splits the name of an interface into its package path and name,
+// e.g. "encoding/json.Marshaler" into "encoding/json" and "Marshaler".
+func splitInterfaceName(name string) (pkg string, typ string) {
+	i := strings.LastIndex(name, ".")
+
+	if i < 0 {
+		return "", name
+	}
+
+	return name[:i], name[i+1:]
+}
+
+// lookupInterfaces finds the well-known interfaces in the program. The packages of interfaces
+// which the program doesn't import are loaded with the build context, if it's set. Interfaces
+// which can't be found are skipped, so that a missing package doesn't stop the signature from
+// being calculated.
+func lookupInterfaces(prog *loader.Program, names []string, ctx *build.Context) []namedInterface {
+	var rv []namedInterface
+
+	missing := []string{}
+
+	for _, name := range names {
+		if pkg, _ := splitInterfaceName(name); pkg != "" && prog.Package(pkg) == nil && !contains(missing, pkg) {
+			missing = append(missing, pkg)
+		}
+	}
+
+	imported := map[string]*types.Package{}
+
+	if ctx != nil && len(missing) > 0 {
+		imported = importInterfacePackages(ctx, missing)
+	}
+
+	for _, name := range names {
+		pkg, typ := splitInterfaceName(name)
+
+		scope := types.Universe
+
+		if pkg != "" {
+			if info := prog.Package(pkg); info != nil {
+				scope = info.Pkg.Scope()
+			} else if p, ok := imported[pkg]; ok {
+				scope = p.Scope()
+			} else {
+				continue
+			}
+		}
+
+		if iface, ok := interfaceOf(scope.Lookup(typ)); ok {
+			rv = append(rv, namedInterface{name: name, iface: iface})
+		}
+	}
+
+	return rv
+}
+
+// importInterfacePackages loads packages of well-known interfaces which the program doesn't
+// import. A package which can't be loaded is skipped with a warning.
+func importInterfacePackages(ctx *build.Context, paths []string) map[string]*types.Package {
+	rv := map[string]*types.Package{}
+
+	if prog, err := loadPackages(ctx, paths); err == nil {
+		for _, p := range paths {
+			rv[p] = prog.Package(p).Pkg
+		}
+
+		return rv
+	}
+
+	// Load each package on its own, so that one which can't be loaded doesn't stop the others
+	// from being used.
+	for _, p := range paths {
+		prog, err := loadPackages(ctx, []string{p})
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping the well-known interfaces of package %s: %v\n", p, err)
+			continue
+		}
+
+		rv[p] = prog.Package(p).Pkg
+	}
+
+	return rv
+}
+
+func loadPackages(ctx *build.Context, paths []string) (*loader.Program, error) {
+	conf := loader.Config{Build: ctx}
+
+	// The errors are returned by Load, rather than being written to stderr.
+	conf.TypeChecker.Error = func(err error) {}
+
+	for _, p := range paths {
+		conf.Import(p)
+	}
+
+	return conf.Load()
+}
+
+// packageInterfaces returns the exported, non-empty interfaces of a package.
+func packageInterfaces(pkg *types.Package) []namedInterface {
+	var rv []namedInterface
+
+	for _, name := range pkg.Scope().Names() {
+		lookup := pkg.Scope().Lookup(name)
+
+		if !lookup.Exported() {
+			continue
+		}
+
+		if iface, ok := interfaceOf(lookup); ok && iface.NumMethods() > 0 {
+			rv = append(rv, namedInterface{name: pkg.Path() + "." + name, iface: iface})
+		}
+	}
+
+	return rv
+}
+
+func interfaceOf(obj types.Object) (*types.Interface, bool) {
+	tn, isTypeName := obj.(*types.TypeName)
+
+	if !isTypeName || tn.IsAlias() {
+		return nil, false
+	}
+
+	if named, isNamed := tn.Type().(*types.Named); isNamed && named.TypeParams() != nil {
+		// Generic interfaces need to be instantiated before they can be implemented.
+		return nil, false
+	}
+
+	iface, isInterface := tn.Type().Underlying().(*types.Interface)
+
+	return iface, isInterface
+}
+
+// addImplements records the interfaces which each exported type in the scope, and a pointer to
+// it, implements.
+func addImplements(sig *Signature, s *types.Scope, interfaces []namedInterface) {
+	for _, name := range s.Names() {
+		tn, isTypeName := s.Lookup(name).(*types.TypeName)
+
+		if !isTypeName || !tn.Exported() || tn.IsAlias() || types.IsInterface(tn.Type()) {
+			continue
+		}
+
+		if named, isNamed := tn.Type().(*types.Named); isNamed && named.TypeParams() != nil {
+			continue
+		}
+
+		for _, t := range []types.Type{tn.Type(), types.NewPointer(tn.Type())} {
+			implemented := []string{}
+
+			for _, i := range interfaces {
+				if types.Implements(t, i.iface) {
+					implemented = append(implemented, i.name)
+				}
+			}
+
+			if len(implemented) == 0 {
+				continue
+			}
+
+			key := name
+
+			if _, isPointer := t.(*types.Pointer); isPointer {
+				key = "*" + name
+			}
+
+			sort.Strings(implemented)
+
+			if sig.Implements == nil {
+				sig.Implements = map[string][]string{}
+			}
+
+			sig.Implements[key] = implemented
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package signature
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestThatImplementedInterfacesAreRecorded(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ver_implements")
+
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	code := `package a
+
+type File struct{}
+
+func (f *File) Read(p []byte) (int, error) { return 0, nil }
+func (f File) Error() string              { return "" }
+
+type Named interface{ Name() string }
+
+type Person struct{}
+
+func (p Person) Name() string { return "" }
+
+type Empty struct{}
+`
+
+	if err = ioutil.WriteFile(path.Join(dir, "a.go"), []byte(code), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	ps, err := GetFromDirectory(os.Getenv("GOPATH"), dir)
+
+	if err != nil {
+		t.Fatalf("failed to get signatures: %v", err)
+	}
+
+	expected := map[string][]string{
+		"File":    []string{"error"},
+		"*File":   []string{"error", "io.Reader"},
+		"Person":  []string{dir + ".Named"},
+		"*Person": []string{dir + ".Named"},
+	}
+
+	if actual := ps[dir].Implements; !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
+
+func TestThatInterfacesWhichCantBeFoundAreSkipped(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ver_implements")
+
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	code := `package a
+
+type File struct{}
+
+func (f *File) Read(p []byte) (int, error) { return 0, nil }
+`
+
+	if err = ioutil.WriteFile(path.Join(dir, "a.go"), []byte(code), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	opts := Options{Interfaces: []string{"github.com/a-h/nonexistent.Reader", "io.Reader"}}
+
+	ps, err := GetFromDirectoryWithOptions(os.Getenv("GOPATH"), dir, opts)
+
+	if err != nil {
+		t.Fatalf("expected the missing interface to be skipped, but got error: %v", err)
+	}
+
+	expected := map[string][]string{
+		"*File": []string{"io.Reader"},
+	}
+
+	if actual := ps[dir].Implements; !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
+
+func TestThatTheInterfacesWhichParametersImplementAreRecorded(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ver_implements")
+
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	code := `package a
+
+import (
+	"bytes"
+	"io"
+)
+
+func Copy(b *bytes.Buffer, r io.Reader, n int) {}
+`
+
+	if err = ioutil.WriteFile(path.Join(dir, "a.go"), []byte(code), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	opts := Options{Interfaces: []string{"io.Reader", "io.Writer", "io.Closer"}}
+
+	ps, err := GetFromDirectoryWithOptions(os.Getenv("GOPATH"), dir, opts)
+
+	if err != nil {
+		t.Fatalf("failed to get signatures: %v", err)
+	}
+
+	expected := []Param{
+		{Name: "b", Type: "*bytes.Buffer", Implements: []string{"io.Reader", "io.Writer"}},
+		{Name: "r", Type: "io.Reader", Interface: true},
+		{Name: "n", Type: "int"},
+	}
+
+	if actual := ps[dir].Funcs["Copy"].Params; !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
@@ -0,0 +1,44 @@
+package signature
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// addInitializers records the expressions that the exported package level variables in
+// the files are initialized with.
+func addInitializers(sig *Signature, files []*ast.File) {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			d, isGenDecl := decl.(*ast.GenDecl)
+
+			if !isGenDecl || d.Tok != token.VAR {
+				continue
+			}
+
+			for _, spec := range d.Specs {
+				vs := spec.(*ast.ValueSpec)
+
+				for i, name := range vs.Names {
+					if !name.IsExported() || len(vs.Values) == 0 {
+						continue
+					}
+
+					// Multiple variables can be initialized from a single function call.
+					value := vs.Values[0]
+
+					if len(vs.Values) == len(vs.Names) {
+						value = vs.Values[i]
+					}
+
+					if sig.Initializers == nil {
+						sig.Initializers = map[string]string{}
+					}
+
+					sig.Initializers[name.Name] = types.ExprString(value)
+				}
+			}
+		}
+	}
+}
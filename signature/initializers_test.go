@@ -0,0 +1,46 @@
+package signature
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+func TestThatInitializersAreRecorded(t *testing.T) {
+	code := `package a
+
+import "errors"
+
+var ErrNotFound = errors.New("not found")
+
+var (
+	Timeout, Retries = 10 * time.Second, 3
+	A, B             = split()
+	private          = 1
+	Uninitialized    int
+)
+`
+
+	f, err := parser.ParseFile(token.NewFileSet(), "a.go", code, 0)
+
+	if err != nil {
+		t.Fatalf("failed to parse Go with error %v", err)
+	}
+
+	sig := NewSignature()
+	addInitializers(&sig, []*ast.File{f})
+
+	expected := map[string]string{
+		"ErrNotFound": `errors.New("not found")`,
+		"Timeout":     "10 * time.Second",
+		"Retries":     "3",
+		"A":           "split()",
+		"B":           "split()",
+	}
+
+	if !reflect.DeepEqual(expected, sig.Initializers) {
+		t.Errorf("expected %v, but got %v", expected, sig.Initializers)
+	}
+}
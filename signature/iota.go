@@ -0,0 +1,53 @@
+package signature
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// addIotaBlocks records the block each exported constant is declared in, if the block uses iota.
+func addIotaBlocks(sig *Signature, files []*ast.File) {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			d, isGenDecl := decl.(*ast.GenDecl)
+
+			if !isGenDecl || d.Tok != token.CONST || len(d.Specs) == 0 || !usesIota(d) {
+				continue
+			}
+
+			first := d.Specs[0].(*ast.ValueSpec).Names[0].Name
+
+			for _, spec := range d.Specs {
+				for _, name := range spec.(*ast.ValueSpec).Names {
+					if !name.IsExported() {
+						continue
+					}
+
+					if sig.IotaBlocks == nil {
+						sig.IotaBlocks = map[string]string{}
+					}
+
+					sig.IotaBlocks[name.Name] = first
+				}
+			}
+		}
+	}
+}
+
+// usesIota returns true if any of the values in the const declaration refer to iota.
+func usesIota(d *ast.GenDecl) bool {
+	found := false
+
+	for _, spec := range d.Specs {
+		for _, v := range spec.(*ast.ValueSpec).Values {
+			ast.Inspect(v, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok && id.Name == "iota" {
+					found = true
+				}
+				return !found
+			})
+		}
+	}
+
+	return found
+}
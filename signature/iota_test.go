@@ -0,0 +1,54 @@
+package signature
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+func TestThatIotaBlocksAreRecorded(t *testing.T) {
+	code := `package a
+
+type Color int
+
+const (
+	unknown Color = iota
+	Red
+	Green
+)
+
+const (
+	Small = 1 << iota
+	Large
+)
+
+const (
+	MaxRetries = 3
+	BufferSize = 1024
+)
+
+const Single = 1
+`
+
+	f, err := parser.ParseFile(token.NewFileSet(), "a.go", code, 0)
+
+	if err != nil {
+		t.Fatalf("failed to parse Go with error %v", err)
+	}
+
+	sig := NewSignature()
+	addIotaBlocks(&sig, []*ast.File{f})
+
+	expected := map[string]string{
+		"Red":   "unknown",
+		"Green": "unknown",
+		"Small": "Small",
+		"Large": "Small",
+	}
+
+	if !reflect.DeepEqual(sig.IotaBlocks, expected) {
+		t.Errorf("expected %v, but got %v", expected, sig.IotaBlocks)
+	}
+}
@@ -0,0 +1,132 @@
+package signature
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+)
+
+// FormatVersion is the version of the JSON document written for a set of PackageSignatures.
+// It is incremented whenever the fields of a signature, or the way items are rendered, change,
+// since diffing signatures written by different versions would report changes to the API
+// which weren't made.
+//
+// Version 2 added positions, deprecations, iota blocks and function details, and renders each
+// field of a struct on its own. Version 3 added the interfaces which parameters implement.
+const FormatVersion = 3
+
+type document struct {
+	Version  int               `json:"version"`
+	Packages []packageDocument `json:"packages"`
+}
+
+type packageDocument struct {
+	Path      string    `json:"path"`
+	Signature Signature `json:"signature"`
+}
+
+// MarshalJSON writes the signatures as a versioned document. Packages are sorted by
+// path, and the items within each signature are sorted, so that the same signatures
+// always produce the same output.
+func (ps PackageSignatures) MarshalJSON() ([]byte, error) {
+	doc := document{
+		Version:  FormatVersion,
+		Packages: make([]packageDocument, 0, len(ps)),
+	}
+
+	for path, sig := range ps {
+		doc.Packages = append(doc.Packages, packageDocument{
+			Path:      path,
+			Signature: sig.sorted(),
+		})
+	}
+
+	sort.Slice(doc.Packages, func(i, j int) bool {
+		return doc.Packages[i].Path < doc.Packages[j].Path
+	})
+
+	return json.Marshal(doc)
+}
+
+// UnmarshalJSON reads a document written by MarshalJSON.
+func (ps *PackageSignatures) UnmarshalJSON(b []byte) error {
+	var doc document
+
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+
+	if doc.Version != FormatVersion {
+		return fmt.Errorf("unsupported signature format version %d, expected %d, please recalculate the signatures with this version of ver", doc.Version, FormatVersion)
+	}
+
+	*ps = make(PackageSignatures, len(doc.Packages))
+
+	for _, p := range doc.Packages {
+		(*ps)[p.Path] = p.Signature
+	}
+
+	return nil
+}
+
+// Save writes the signatures to a file in JSON format.
+func Save(filename string, ps PackageSignatures) error {
+	b, err := json.Marshal(ps)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal signatures: %v", err)
+	}
+
+	return ioutil.WriteFile(filename, b, 0644)
+}
+
+// Load reads signatures previously written by Save.
+func Load(filename string) (PackageSignatures, error) {
+	b, err := ioutil.ReadFile(filename)
+
+	if err != nil {
+		return PackageSignatures{}, err
+	}
+
+	var ps PackageSignatures
+
+	if err = json.Unmarshal(b, &ps); err != nil {
+		return PackageSignatures{}, fmt.Errorf("failed to read signatures from %s: %v", filename, err)
+	}
+
+	return ps, nil
+}
+
+// sorted returns a copy of the signature with each of its items sorted.
+func (s Signature) sorted() Signature {
+	for _, items := range s.lists() {
+		*items = sortedCopy(*items)
+	}
+
+	return s
+}
+
+// lists returns pointers to each of the lists of items in the signature.
+func (s *Signature) lists() []*[]string {
+	return []*[]string{
+		&s.Functions,
+		&s.Fields,
+		&s.Constants,
+		&s.Structs,
+		&s.Interfaces,
+		&s.Types,
+	}
+}
+
+func sortedCopy(items []string) []string {
+	if items == nil {
+		return nil
+	}
+
+	rv := make([]string, len(items))
+	copy(rv, items)
+	sort.Strings(rv)
+
+	return rv
+}
@@ -0,0 +1,118 @@
+package signature
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestThatSignaturesAreWrittenInASortedVersionedFormat(t *testing.T) {
+	ps := PackageSignatures{
+		"packageB": Signature{
+			Functions: []string{"func b() string", "func a() string"},
+		},
+		"packageA": Signature{
+			Constants: []string{"const y = 1", "const x = 0"},
+		},
+	}
+
+	b, err := json.Marshal(ps)
+
+	if err != nil {
+		t.Fatalf("failed to marshal signatures: %v", err)
+	}
+
+	expected := `{"version":3,"packages":[` +
+		`{"path":"packageA","signature":{"functions":null,"fields":null,"constants":["const x = 0","const y = 1"],"structs":null,"interfaces":null,"types":null}},` +
+		`{"path":"packageB","signature":{"functions":["func a() string","func b() string"],"fields":null,"constants":null,"structs":null,"interfaces":null,"types":null}}]}`
+
+	if string(b) != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, string(b))
+	}
+
+	if ps["packageB"].Functions[0] != "func b() string" {
+		t.Errorf("marshalling should not sort the original signature, but got %v", ps["packageB"].Functions)
+	}
+}
+
+func TestThatSignaturesCanBeSavedAndLoaded(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ver_signature")
+
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	expected := PackageSignatures{
+		"packageA": Signature{
+			Functions: []string{"func a() string", "func b() string"},
+			Structs:   []string{"struct Test {}"},
+		},
+	}
+
+	filename := path.Join(dir, "signature.json")
+
+	if err = Save(filename, expected); err != nil {
+		t.Fatalf("failed to save signatures: %v", err)
+	}
+
+	actual, err := Load(filename)
+
+	if err != nil {
+		t.Fatalf("failed to load signatures: %v", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
+
+func TestThatUnsupportedFormatVersionsAreRejected(t *testing.T) {
+	for _, doc := range []string{
+		`{"version":1000,"packages":[]}`,
+		`{"version":1,"packages":[{"path":"a","signature":{"functions":["func a.A()"]}}]}`,
+		`{"packages":[]}`,
+	} {
+		var ps PackageSignatures
+
+		if err := json.Unmarshal([]byte(doc), &ps); err == nil {
+			t.Errorf("expected an error reading %s, but got nil", doc)
+		}
+	}
+}
+
+func TestThatImportPathsAreCalculatedRelativeToTheGopath(t *testing.T) {
+	tests := []struct {
+		gopath   string
+		dir      string
+		expected string
+	}{
+		{
+			gopath:   "/tmp/ver_history123",
+			dir:      "/tmp/ver_history123/src/github.com/a-h/ver",
+			expected: "github.com/a-h/ver",
+		},
+		{
+			gopath:   "/go" + string(os.PathListSeparator) + "/tmp/ver_history123",
+			dir:      "/tmp/ver_history123/src/github.com/a-h/ver/diff",
+			expected: "github.com/a-h/ver/diff",
+		},
+		{
+			gopath:   "/go",
+			dir:      "/home/user/ver",
+			expected: "/home/user/ver",
+		},
+	}
+
+	for _, test := range tests {
+		actual := importPath(test.gopath, test.dir)
+
+		if actual != test.expected {
+			t.Errorf("for gopath %s and dir %s; expected '%s', but got '%s'", test.gopath, test.dir, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package signature
+
+import "go/types"
+
+// Layout is the memory layout of a struct on an architecture.
+type Layout struct {
+	Size  int64 `json:"size"`
+	Align int64 `json:"align"`
+	// Offsets are the offsets of every field of the struct, including unexported fields, in the
+	// order they're declared.
+	Offsets []int64 `json:"offsets"`
+}
+
+// addLayouts records the memory layout of each struct in the signature for the architecture,
+// e.g. "amd64". It does nothing if the architecture isn't known to the gc compiler.
+func addLayouts(sig *Signature, s *types.Scope, arch string) {
+	sizes := types.SizesFor("gc", arch)
+
+	if sizes == nil {
+		return
+	}
+
+	for name, details := range sig.StructDetails {
+		lookup := s.Lookup(name)
+
+		if lookup == nil {
+			continue
+		}
+
+		st, isStruct := lookup.Type().Underlying().(*types.Struct)
+
+		if !isStruct {
+			continue
+		}
+
+		fields := make([]*types.Var, st.NumFields())
+
+		for fi := range fields {
+			fields[fi] = st.Field(fi)
+		}
+
+		if details.Layouts == nil {
+			details.Layouts = map[string]Layout{}
+		}
+
+		details.Layouts[arch] = Layout{
+			Size:    sizes.Sizeof(st),
+			Align:   sizes.Alignof(st),
+			Offsets: sizes.Offsetsof(fields),
+		}
+
+		sig.StructDetails[name] = details
+	}
+}
@@ -0,0 +1,29 @@
+package signature
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestThatStructLayoutsAreRecordedForEachArchitecture(t *testing.T) {
+	pkg, err := parseGoIntoPackage("github.com/a-h/nonexistent", "package nonexistent\ntype Test struct { A int8; b int64; C string }")
+
+	if err != nil {
+		t.Fatalf("failed to parse Go with error %v", err)
+	}
+
+	sig := GetFromScope(pkg.Scope())
+
+	addLayouts(&sig, pkg.Scope(), "amd64")
+	addLayouts(&sig, pkg.Scope(), "386")
+	addLayouts(&sig, pkg.Scope(), "unknown")
+
+	expected := map[string]Layout{
+		"amd64": Layout{Size: 32, Align: 8, Offsets: []int64{0, 8, 16}},
+		"386":   Layout{Size: 20, Align: 4, Offsets: []int64{0, 4, 12}},
+	}
+
+	if actual := sig.StructDetails["Test"].Layouts; !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
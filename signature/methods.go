@@ -0,0 +1,60 @@
+package signature
+
+import "go/types"
+
+// MethodSet lists the names of the exported methods in the method set of a type T, and of a
+// pointer to the type, *T. Methods with a pointer receiver are only in the method set of *T,
+// so only *T satisfies interfaces which require them.
+type MethodSet struct {
+	Value   []string `json:"value"`
+	Pointer []string `json:"pointer"`
+}
+
+// HasValue returns true if the method is in the method set of T.
+func (ms MethodSet) HasValue(name string) bool {
+	return contains(ms.Value, name)
+}
+
+// HasPointer returns true if the method is in the method set of *T.
+func (ms MethodSet) HasPointer(name string) bool {
+	return contains(ms.Pointer, name)
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newMethodSet(t types.Type) MethodSet {
+	return MethodSet{
+		Value:   methodNames(t),
+		Pointer: methodNames(types.NewPointer(t)),
+	}
+}
+
+func methodNames(t types.Type) []string {
+	var rv []string
+
+	mset := types.NewMethodSet(t)
+
+	for i := 0; i < mset.Len(); i++ {
+		if method := mset.At(i).Obj(); method.Exported() {
+			rv = append(rv, method.Name())
+		}
+	}
+
+	return rv
+}
+
+func (s *Signature) setMethodSet(name string, ms MethodSet) {
+	if s.MethodSets == nil {
+		s.MethodSets = map[string]MethodSet{}
+	}
+
+	s.MethodSets[name] = ms
+}
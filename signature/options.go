@@ -0,0 +1,142 @@
+package signature
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Options configures which files and packages are included in a signature.
+type Options struct {
+	// ExcludeGenerated excludes files marked with a "// Code generated ... DO NOT EDIT." comment.
+	ExcludeGenerated bool
+	// Exclude is a list of glob patterns of files and directories to exclude. Each pattern is
+	// matched against the path relative to the directory being analysed, and against the name of
+	// the file or directory, e.g. "internal/mocks" or "*_mock.go".
+	Exclude []string
+	// IncludeInternal includes internal packages, which can't be imported by other modules, in the API.
+	IncludeInternal bool
+	// IncludeMain includes main packages, which can't be imported, in the API.
+	IncludeMain bool
+	// Platforms is the set of platforms to calculate the signature for. If it's empty,
+	// the signature is calculated for the current platform.
+	Platforms []Platform
+	// Layout records the size, alignment and field offsets of each struct, for the architecture
+	// of each of the Platforms, or the current architecture if there are no Platforms.
+	Layout bool
+	// Interfaces are the well-known interfaces to record the implementations of, as well as the
+	// exported interfaces of the packages being analysed, e.g. "io.Reader" or "encoding/json.Marshaler".
+	// DefaultInterfaces are used if it's nil.
+	Interfaces []string
+	// Initializers records the expression each exported variable is initialized with, e.g.
+	// `errors.New("not found")`, so that changes to default values can be reported.
+	Initializers bool
+}
+
+// includePackage follows Go's import rules, excluding main packages and internal packages, which
+// can't be imported from outside of the directory being analysed, unless the options include them.
+func (o Options) includePackage(prefix string, path string, name string) bool {
+	if name == "main" && !o.IncludeMain {
+		return false
+	}
+
+	if o.IncludeInternal {
+		return true
+	}
+
+	for _, segment := range strings.Split(strings.TrimPrefix(path, prefix), "/") {
+		if segment == "internal" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// includeDir follows the rules of the go tool, skipping testdata and vendor directories, and
+// directories which start with "_" or ".".
+func (o Options) includeDir(root string, dir string) bool {
+	if dir == root {
+		return true
+	}
+
+	name := filepath.Base(dir)
+
+	if name == "testdata" || name == "vendor" || strings.HasPrefix(name, "_") || strings.HasPrefix(name, ".") {
+		return false
+	}
+
+	return !o.excluded(root, dir)
+}
+
+// includeFile follows the rules of the go tool, skipping test files and files which start with
+// "_" or ".", then applies the exclusions of the options.
+func (o Options) includeFile(root string, filename string) bool {
+	name := filepath.Base(filename)
+
+	if filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") ||
+		strings.HasPrefix(name, "_") || strings.HasPrefix(name, ".") {
+		return false
+	}
+
+	if o.excluded(root, filename) {
+		return false
+	}
+
+	return !o.ExcludeGenerated || !isGenerated(filename)
+}
+
+func (o Options) excluded(root string, p string) bool {
+	rel, err := filepath.Rel(root, p)
+
+	if err != nil {
+		return false
+	}
+
+	rel = filepath.ToSlash(rel)
+	name := filepath.Base(p)
+
+	for _, pattern := range o.Exclude {
+		if matched, _ := filepath.Match(pattern, rel); matched {
+			return true
+		}
+
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+var generatedComment = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// isGenerated returns true if the file has a "// Code generated ... DO NOT EDIT." comment
+// before its package clause, see https://golang.org/s/generatedcode
+func isGenerated(filename string) bool {
+	f, err := os.Open(filename)
+
+	if err != nil {
+		return false
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+
+		if generatedComment.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
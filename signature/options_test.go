@@ -0,0 +1,163 @@
+package signature
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestThatFilesAreSelectedUsingTheRulesOfTheGoTool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ver_options")
+
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"a.go":                "package a",
+		"a_test.go":           "package a",
+		"_ignored.go":         "package a",
+		"README.md":           "# Test",
+		"generated.go":        "// Code generated by stringer; DO NOT EDIT.\n\npackage a",
+		"client_mock.go":      "package a",
+		"sub/b.go":            "package b",
+		"testdata/c.go":       "package c",
+		"vendor/d/d.go":       "package d",
+		"_examples/e.go":      "package e",
+		".hidden/f.go":        "package f",
+		"internal/mocks/g.go": "package mocks",
+	}
+
+	for name, content := range files {
+		filename := filepath.Join(dir, name)
+
+		if err = os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+
+		if err = ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		opts     Options
+		expected []string
+	}{
+		{
+			name:     "Default options",
+			opts:     Options{},
+			expected: []string{"a.go", "client_mock.go", "generated.go", "internal/mocks/g.go", "sub/b.go"},
+		},
+		{
+			name:     "Generated files excluded",
+			opts:     Options{ExcludeGenerated: true},
+			expected: []string{"a.go", "client_mock.go", "internal/mocks/g.go", "sub/b.go"},
+		},
+		{
+			name:     "Glob patterns excluded",
+			opts:     Options{Exclude: []string{"*_mock.go", "internal/mocks"}},
+			expected: []string{"a.go", "generated.go", "sub/b.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		directories, err := walkDirectories(dir, tt.opts)
+
+		if err != nil {
+			t.Fatalf("%s - failed to walk directories: %v", tt.name, err)
+		}
+
+		actual := []string{}
+
+		for _, d := range directories {
+			filenames, err := getFiles(dir, d, tt.opts)
+
+			if err != nil {
+				t.Fatalf("%s - failed to get files: %v", tt.name, err)
+			}
+
+			for _, f := range filenames {
+				rel, _ := relativeFile(dir, f)
+				actual = append(actual, rel)
+			}
+		}
+
+		sort.Strings(actual)
+
+		if !reflect.DeepEqual(tt.expected, actual) {
+			t.Errorf("%s - expected files %v, but got %v", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestThatInternalAndMainPackagesAreExcludedByDefault(t *testing.T) {
+	prefix := "github.com/a-h/ver"
+
+	tests := []struct {
+		name     string
+		opts     Options
+		path     string
+		pkg      string
+		expected bool
+	}{
+		{
+			name:     "Library package",
+			path:     "github.com/a-h/ver/signature",
+			pkg:      "signature",
+			expected: true,
+		},
+		{
+			name:     "Main package",
+			path:     "github.com/a-h/ver",
+			pkg:      "main",
+			expected: false,
+		},
+		{
+			name:     "Main package included",
+			opts:     Options{IncludeMain: true},
+			path:     "github.com/a-h/ver",
+			pkg:      "main",
+			expected: true,
+		},
+		{
+			name:     "Internal package",
+			path:     "github.com/a-h/ver/internal/cache",
+			pkg:      "cache",
+			expected: false,
+		},
+		{
+			name:     "Nested internal package",
+			path:     "github.com/a-h/ver/diff/internal",
+			pkg:      "internal",
+			expected: false,
+		},
+		{
+			name:     "Internal package included",
+			opts:     Options{IncludeInternal: true},
+			path:     "github.com/a-h/ver/internal/cache",
+			pkg:      "cache",
+			expected: true,
+		},
+		{
+			name:     "Package with internal in its name",
+			path:     "github.com/a-h/ver/internals",
+			pkg:      "internals",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		actual := tt.opts.includePackage(prefix, tt.path, tt.pkg)
+
+		if actual != tt.expected {
+			t.Errorf("%s - expected %v, but got %v", tt.name, tt.expected, actual)
+		}
+	}
+}
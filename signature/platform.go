@@ -0,0 +1,147 @@
+package signature
+
+import (
+	"fmt"
+	"go/build"
+	"path/filepath"
+	"strings"
+)
+
+// Platform is an operating system, architecture and set of build tags to calculate a signature for.
+type Platform struct {
+	GOOS   string
+	GOARCH string
+	Tags   []string
+}
+
+// ParsePlatform parses a platform in the format "goos/goarch", optionally followed by a
+// comma separated list of build tags, e.g. "linux/amd64" or "linux/amd64:netgo,osusergo".
+func ParsePlatform(s string) (Platform, error) {
+	p := Platform{}
+
+	target := s
+
+	if i := strings.Index(s, ":"); i >= 0 {
+		target = s[:i]
+
+		for _, tag := range strings.Split(s[i+1:], ",") {
+			if tag != "" {
+				p.Tags = append(p.Tags, tag)
+			}
+		}
+	}
+
+	parts := strings.Split(target, "/")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return p, fmt.Errorf("invalid platform '%s', expected the format goos/goarch[:tag1,tag2]", s)
+	}
+
+	p.GOOS, p.GOARCH = parts[0], parts[1]
+
+	return p, nil
+}
+
+func (p Platform) String() string {
+	s := p.GOOS + "/" + p.GOARCH
+
+	if len(p.Tags) > 0 {
+		s += ":" + strings.Join(p.Tags, ",")
+	}
+
+	return s
+}
+
+func (p Platform) context(gopath string) build.Context {
+	ctx := build.Default
+	ctx.GOPATH = gopath
+
+	// Cgo can only be used for the current platform.
+	if p.GOOS != ctx.GOOS || p.GOARCH != ctx.GOARCH {
+		ctx.CgoEnabled = false
+	}
+
+	ctx.GOOS = p.GOOS
+	ctx.GOARCH = p.GOARCH
+	ctx.BuildTags = p.Tags
+
+	return ctx
+}
+
+// matchFiles returns the files which are built for the platform and build tags of the context.
+func matchFiles(ctx build.Context, filenames []string) []string {
+	rv := []string{}
+
+	for _, filename := range filenames {
+		match, err := ctx.MatchFile(filepath.Dir(filename), filepath.Base(filename))
+
+		// Files which can't be read are left for the loader to report.
+		if match || err != nil {
+			rv = append(rv, filename)
+		}
+	}
+
+	return rv
+}
+
+// mergePlatforms merges the signatures calculated for each platform into a single set of
+// signatures, recording the platforms that each item is available on.
+func mergePlatforms(platforms []Platform, signatures map[string]PackageSignatures) PackageSignatures {
+	names := []string{}
+
+	for _, p := range platforms {
+		names = append(names, p.String())
+	}
+
+	rv := PackageSignatures{}
+	availability := map[string]map[string][]string{}
+
+	for _, platform := range names {
+		for pkg, sig := range signatures[platform] {
+			merged, ok := rv[pkg]
+
+			if !ok {
+				merged = NewSignature()
+				merged.Platforms = names
+				availability[pkg] = map[string][]string{}
+			}
+
+			mergedLists := merged.lists()
+
+			for i, items := range sig.lists() {
+				for _, item := range *items {
+					if _, seen := availability[pkg][item]; !seen {
+						*mergedLists[i] = append(*mergedLists[i], item)
+					}
+
+					if a := availability[pkg][item]; len(a) == 0 || a[len(a)-1] != platform {
+						availability[pkg][item] = append(a, platform)
+					}
+				}
+			}
+
+			merged.mergeDetails(sig)
+			rv[pkg] = merged
+		}
+	}
+
+	for pkg, items := range availability {
+		sig := rv[pkg]
+
+		for item, available := range items {
+			if len(available) == len(names) {
+				continue
+			}
+
+			if sig.Availability == nil {
+				sig.Availability = map[string][]string{}
+			}
+
+			sig.Availability[item] = available
+		}
+
+		rv[pkg] = sig.sorted()
+	}
+
+	return rv
+}
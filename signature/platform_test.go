@@ -0,0 +1,113 @@
+package signature
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestThatPlatformsCanBeParsed(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Platform
+		err      bool
+	}{
+		{
+			input:    "linux/amd64",
+			expected: Platform{GOOS: "linux", GOARCH: "amd64"},
+		},
+		{
+			input:    "windows/386:netgo,osusergo",
+			expected: Platform{GOOS: "windows", GOARCH: "386", Tags: []string{"netgo", "osusergo"}},
+		},
+		{
+			input: "linux",
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := ParsePlatform(test.input)
+
+		if test.err != (err != nil) {
+			t.Errorf("for %v; expected error %v, but got %v", test.input, test.err, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("for %v; expected %v, but got %v", test.input, test.expected, actual)
+		}
+
+		if !test.err && actual.String() != test.input {
+			t.Errorf("for %v; expected String() to return the input, but got %v", test.input, actual.String())
+		}
+	}
+}
+
+func TestThatSignaturesAreMergedAcrossPlatforms(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ver_platform")
+
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"a.go":         "package a\n\nfunc A() {}\n",
+		"b_windows.go": "package a\n\nfunc B() {}\n",
+		"c_linux.go":   "package a\n\nfunc C() {}\n",
+		"d.go":         "//go:build tag1\n\npackage a\n\nfunc D() {}\n",
+	}
+
+	for name, content := range files {
+		if err = ioutil.WriteFile(path.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	opts := Options{
+		Platforms: []Platform{
+			{GOOS: "linux", GOARCH: "amd64"},
+			{GOOS: "windows", GOARCH: "amd64"},
+			{GOOS: "linux", GOARCH: "amd64", Tags: []string{"tag1"}},
+		},
+	}
+
+	ps, err := GetFromDirectoryWithOptions(os.Getenv("GOPATH"), dir, opts)
+
+	if err != nil {
+		t.Fatalf("failed to get signatures: %v", err)
+	}
+
+	sig, ok := ps[dir]
+
+	if !ok {
+		t.Fatalf("expected a signature for %s, but got %v", dir, ps)
+	}
+
+	expectedPlatforms := []string{"linux/amd64", "windows/amd64", "linux/amd64:tag1"}
+
+	if !reflect.DeepEqual(sig.Platforms, expectedPlatforms) {
+		t.Errorf("expected platforms %v, but got %v", expectedPlatforms, sig.Platforms)
+	}
+
+	expected := map[string][]string{
+		"func " + dir + ".A()": expectedPlatforms,
+		"func " + dir + ".B()": []string{"windows/amd64"},
+		"func " + dir + ".C()": []string{"linux/amd64", "linux/amd64:tag1"},
+		"func " + dir + ".D()": []string{"linux/amd64:tag1"},
+	}
+
+	if len(sig.Functions) != len(expected) {
+		t.Errorf("expected %d functions, but got %v", len(expected), sig.Functions)
+	}
+
+	for item, platforms := range expected {
+		if actual := sig.AvailableOn(item); !reflect.DeepEqual(actual, platforms) {
+			t.Errorf("expected %s to be available on %v, but got %v", item, platforms, actual)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package signature
+
+import (
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"strings"
+)
+
+// newPositions converts the positions of items to file names relative to dir, and line numbers.
+// Items which are declared outside of dir aren't included.
+func newPositions(fset *token.FileSet, dir string, positions map[string]token.Pos) map[string]Position {
+	if len(positions) == 0 {
+		return nil
+	}
+
+	rv := make(map[string]Position, len(positions))
+
+	for item, pos := range positions {
+		if !pos.IsValid() {
+			continue
+		}
+
+		p := fset.Position(pos)
+
+		// Absolute paths would differ between machines, and between runs, since dependencies
+		// are fetched to a temporary directory.
+		file, ok := relativeFile(dir, p.Filename)
+		if !ok {
+			continue
+		}
+
+		rv[item] = Position{File: file, Line: p.Line}
+	}
+
+	if len(rv) == 0 {
+		return nil
+	}
+
+	return rv
+}
+
+// relativeFile returns the path of the file relative to dir, or false if it isn't in dir.
+func relativeFile(dir string, filename string) (string, bool) {
+	if dir == "" {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(dir, filename)
+
+	if err != nil || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return filepath.ToSlash(rel), true
+}
+
+// String returns the position in the form "file:line".
+func (p Position) String() string {
+	return fmt.Sprintf("%s:%d", p.File, p.Line)
+}
@@ -0,0 +1,127 @@
+package signature
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestThatThePositionsOfItemsAreExtracted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ver_positions")
+
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"a.go":     "package a\n\n// A is a function.\nfunc A() {}\n\nconst C = 1\n",
+		"sub/b.go": "package sub\n\ntype T struct{}\n\nfunc (t T) M() {}\n",
+	}
+
+	for name, content := range files {
+		filename := path.Join(dir, name)
+
+		if err = os.MkdirAll(path.Dir(filename), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+
+		if err = ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	ps, err := GetFromDirectory(os.Getenv("GOPATH"), dir)
+
+	if err != nil {
+		t.Fatalf("failed to get signatures: %v", err)
+	}
+
+	a := ps[dir]
+	sub := ps[dir+"/sub"]
+
+	expected := map[string]Position{
+		"func " + dir + ".A()":                Position{File: "a.go", Line: 4},
+		"const " + dir + ".C untyped int = 1": Position{File: "a.go", Line: 6},
+	}
+
+	if !reflect.DeepEqual(a.Positions, expected) {
+		t.Errorf("expected positions %v, but got %v", expected, a.Positions)
+	}
+
+	expected = map[string]Position{
+		"struct T {}":                     Position{File: "sub/b.go", Line: 3},
+		"method (" + dir + "/sub.T) M()":  Position{File: "sub/b.go", Line: 5},
+		"method (*" + dir + "/sub.T) M()": Position{File: "sub/b.go", Line: 5},
+	}
+
+	if !reflect.DeepEqual(sub.Positions, expected) {
+		t.Errorf("expected positions %v, but got %v", expected, sub.Positions)
+	}
+
+	if s := expected["struct T {}"].String(); s != "sub/b.go:3" {
+		t.Errorf("expected the position to be formatted as sub/b.go:3, but got %s", s)
+	}
+}
+
+func TestThatPromotedMethodsArePositionedAtTheEmbeddingType(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ver_positions")
+
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	content := "package a\n\nimport \"strings\"\n\n// R embeds a type from another package.\ntype R struct {\n\tstrings.Reader\n}\n"
+
+	if err = ioutil.WriteFile(path.Join(dir, "a.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	ps, err := GetFromDirectory(os.Getenv("GOPATH"), dir)
+
+	if err != nil {
+		t.Fatalf("failed to get signatures: %v", err)
+	}
+
+	positions := ps[dir].Positions
+
+	if p, ok := positions["method (*"+dir+".R) Len() int"]; !ok || p != (Position{File: "a.go", Line: 6}) {
+		t.Errorf("expected the promoted method to be positioned at a.go:6, but got %v", positions)
+	}
+
+	for item, p := range positions {
+		if filepath.IsAbs(p.File) || strings.HasPrefix(p.File, "..") {
+			t.Errorf("expected the position of '%s' to be relative to the directory, but got %s", item, p.File)
+		}
+	}
+}
+
+func TestThatFilesOutsideTheDirectoryAreNotRelative(t *testing.T) {
+	tests := []struct {
+		dir      string
+		filename string
+		expected string
+		ok       bool
+	}{
+		{dir: "/src/a", filename: "/src/a/b/c.go", expected: "b/c.go", ok: true},
+		{dir: "/src/a", filename: "/src/b/c.go", ok: false},
+		{dir: "/src/a", filename: "/usr/lib/go/src/strings/reader.go", ok: false},
+		{dir: "/src/a", filename: "/src/a/..b/c.go", expected: "..b/c.go", ok: true},
+		{dir: "", filename: "/src/a/c.go", ok: false},
+	}
+
+	for _, test := range tests {
+		actual, ok := relativeFile(test.dir, test.filename)
+
+		if actual != test.expected || ok != test.ok {
+			t.Errorf("for '%s' in '%s', expected '%s', %v, but got '%s', %v", test.filename, test.dir, test.expected, test.ok, actual, ok)
+		}
+	}
+}
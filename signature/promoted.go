@@ -0,0 +1,137 @@
+package signature
+
+import (
+	"go/types"
+	"strings"
+)
+
+// Promoted is an exported method or field which is promoted from an embedded type.
+type Promoted struct {
+	Name string `json:"name"`
+	// Kind is "method" or "field".
+	Kind string `json:"kind"`
+	// Type is the type of the field, or the signature of the method, e.g. "func(p []byte) (n int, err error)".
+	Type string `json:"type"`
+	// Via is the path of embedded fields the method or field is promoted through, e.g. "Reader" or "Base.Reader".
+	Via string `json:"via"`
+	// Origin is the path of the package which declares the method or field, e.g. "io".
+	Origin string `json:"origin"`
+}
+
+// promoted returns the exported methods and fields which are promoted to the named type t from its
+// embedded fields, in the order of the method set, followed by the fields.
+func promoted(t types.Type) []Promoted {
+	var rv []Promoted
+
+	mset := types.NewMethodSet(types.NewPointer(t))
+
+	for i := 0; i < mset.Len(); i++ {
+		sel := mset.At(i)
+
+		if len(sel.Index()) < 2 || !sel.Obj().Exported() {
+			continue
+		}
+
+		rv = append(rv, Promoted{
+			Name:   sel.Obj().Name(),
+			Kind:   "method",
+			Type:   types.TypeString(sel.Obj().Type(), nil),
+			Via:    via(t, sel.Index()),
+			Origin: packagePath(sel.Obj().Pkg()),
+		})
+	}
+
+	for _, name := range embeddedFieldNames(t, map[types.Type]bool{}) {
+		obj, index, _ := types.LookupFieldOrMethod(t, true, nil, name)
+
+		field, isField := obj.(*types.Var)
+
+		if !isField || len(index) < 2 {
+			continue
+		}
+
+		rv = append(rv, Promoted{
+			Name:   name,
+			Kind:   "field",
+			Type:   types.TypeString(field.Type(), nil),
+			Via:    via(t, index),
+			Origin: packagePath(field.Pkg()),
+		})
+	}
+
+	return rv
+}
+
+// embeddedFieldNames returns the names of the exported fields of the types embedded in t, and
+// the types embedded in them. Fields which are shadowed or ambiguous are filtered out by looking
+// each one up.
+func embeddedFieldNames(t types.Type, seen map[types.Type]bool) []string {
+	var rv []string
+
+	st, isStruct := deref(t).Underlying().(*types.Struct)
+
+	if !isStruct || seen[t] {
+		return rv
+	}
+
+	seen[t] = true
+
+	for fi := 0; fi < st.NumFields(); fi++ {
+		field := st.Field(fi)
+
+		if !field.Embedded() {
+			continue
+		}
+
+		if es, ok := deref(field.Type()).Underlying().(*types.Struct); ok {
+			for efi := 0; efi < es.NumFields(); efi++ {
+				if ef := es.Field(efi); ef.Exported() && !contains(rv, ef.Name()) {
+					rv = append(rv, ef.Name())
+				}
+			}
+		}
+
+		for _, name := range embeddedFieldNames(field.Type(), seen) {
+			if !contains(rv, name) {
+				rv = append(rv, name)
+			}
+		}
+	}
+
+	return rv
+}
+
+// via returns the names of the embedded fields which lead to the field or method at the index.
+func via(t types.Type, index []int) string {
+	names := []string{}
+
+	for _, i := range index[:len(index)-1] {
+		st, isStruct := deref(t).Underlying().(*types.Struct)
+
+		if !isStruct {
+			break
+		}
+
+		field := st.Field(i)
+		names = append(names, field.Name())
+		t = field.Type()
+	}
+
+	return strings.Join(names, ".")
+}
+
+func deref(t types.Type) types.Type {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		return p.Elem()
+	}
+
+	return t
+}
+
+func packagePath(pkg *types.Package) string {
+	if pkg == nil {
+		return ""
+	}
+
+	return pkg.Path()
+}
@@ -0,0 +1,120 @@
+package signature
+
+import (
+	"go/types"
+	"sort"
+)
+
+// reachable returns the unexported types declared in the scope which can be used through its
+// exported declarations, e.g. the unexported result of an exported function, or the type of an
+// exported field. The exported methods and fields of the types can be used, even though the
+// types can't be named outside of the package.
+func reachable(s *types.Scope) []*types.TypeName {
+	r := reacher{
+		seen:  map[types.Type]bool{},
+		found: map[string]*types.TypeName{},
+	}
+
+	for _, name := range s.Names() {
+		lookup := s.Lookup(name)
+
+		if !lookup.Exported() {
+			continue
+		}
+
+		r.pkg = lookup.Pkg()
+		r.visit(lookup.Type())
+	}
+
+	names := []string{}
+
+	for name := range r.found {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	rv := []*types.TypeName{}
+
+	for _, name := range names {
+		rv = append(rv, r.found[name])
+	}
+
+	return rv
+}
+
+type reacher struct {
+	pkg   *types.Package
+	seen  map[types.Type]bool
+	found map[string]*types.TypeName
+}
+
+func (r reacher) visit(t types.Type) {
+	if t == nil || r.seen[t] {
+		return
+	}
+
+	r.seen[t] = true
+
+	switch t := t.(type) {
+	case *types.Alias:
+		r.visit(types.Unalias(t))
+	case *types.Named:
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			r.visit(t.TypeArgs().At(i))
+		}
+
+		obj := t.Obj()
+
+		if obj.Pkg() != r.pkg {
+			// Types from other packages have their own signatures.
+			return
+		}
+
+		if !obj.Exported() && obj.Parent() == r.pkg.Scope() {
+			r.found[obj.Name()] = obj
+		}
+
+		r.visit(t.Underlying())
+
+		for _, mt := range []types.Type{t, types.NewPointer(t)} {
+			mset := types.NewMethodSet(mt)
+
+			for i := 0; i < mset.Len(); i++ {
+				if method := mset.At(i).Obj(); method.Exported() {
+					r.visit(method.Type())
+				}
+			}
+		}
+	case *types.Pointer:
+		r.visit(t.Elem())
+	case *types.Slice:
+		r.visit(t.Elem())
+	case *types.Array:
+		r.visit(t.Elem())
+	case *types.Map:
+		r.visit(t.Key())
+		r.visit(t.Elem())
+	case *types.Chan:
+		r.visit(t.Elem())
+	case *types.Signature:
+		r.visit(t.Params())
+		r.visit(t.Results())
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			r.visit(t.At(i).Type())
+		}
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if f := t.Field(i); f.Exported() {
+				r.visit(f.Type())
+			}
+		}
+	case *types.Interface:
+		for i := 0; i < t.NumMethods(); i++ {
+			if m := t.Method(i); m.Exported() {
+				r.visit(m.Type())
+			}
+		}
+	}
+}
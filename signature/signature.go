@@ -1,9 +1,10 @@
 package signature
 
 import (
-	"bytes"
 	"fmt"
 	"go/build"
+	"go/parser"
+	"go/token"
 	"go/types"
 	"io/ioutil"
 	"os"
@@ -29,44 +30,173 @@ type Signature struct {
 	Constants  []string `json:"constants"`
 	Structs    []string `json:"structs"`
 	Interfaces []string `json:"interfaces"`
+	// Types are named types which aren't structs or interfaces, along with their underlying
+	// type, e.g. "type github.com/a-h/ver.Mode int", and aliases, e.g. "type github.com/a-h/ver.A = int".
+	Types []string `json:"types"`
+	// StructDetails are the fields of each struct in Structs, keyed by the name of the struct.
+	StructDetails map[string]Struct `json:"structDetails,omitempty"`
+	// MethodSets are the method sets of each named type which isn't an interface, keyed by the
+	// name of the type.
+	MethodSets map[string]MethodSet `json:"methodSets,omitempty"`
+	// Implements lists the interfaces each exported type implements, keyed by the name of the type,
+	// or the name prefixed with "*" for a pointer to the type, e.g. "*Git": ["io.Closer"].
+	Implements map[string][]string `json:"implements,omitempty"`
+	// Initializers are the expressions exported variables are initialized with, keyed by the
+	// name of the variable. They're only recorded when the Initializers option is set.
+	Initializers map[string]string `json:"initializers,omitempty"`
+	// Funcs are the parameters and results of each function and method in Functions, keyed by the
+	// name of the function, e.g. "Clone", or the method, e.g. "(*Git).Log".
+	Funcs map[string]Func `json:"funcs,omitempty"`
+	// IotaBlocks maps each exported constant which is declared in a block of constants which
+	// uses iota to the name of the first constant in the block, e.g. {"Red": "Red", "Green": "Red"}.
+	IotaBlocks map[string]string `json:"iotaBlocks,omitempty"`
+	// Deprecated are the items which have a "Deprecated: " paragraph in their doc comment, keyed
+	// by the name of the item, e.g. "Clone" or "(*Git).Log", along with the text of the paragraph.
+	Deprecated map[string]string `json:"deprecated,omitempty"`
+	// Positions are where each item is declared, keyed by the item. They're only recorded
+	// for signatures which were type checked.
+	Positions map[string]Position `json:"positions,omitempty"`
+	// Approximate is set when the signature was read from the source without type checking it.
+	Approximate bool `json:"approximate,omitempty"`
+	// Platforms are the platforms the signature was calculated for, e.g. "linux/amd64".
+	// It's empty when the signature was calculated for the current platform only.
+	Platforms []string `json:"platforms,omitempty"`
+	// Availability maps items which aren't available on all of the Platforms to the
+	// platforms they're available on.
+	Availability map[string][]string `json:"availability,omitempty"`
+}
+
+// AvailableOn returns the platforms an item is available on, or nil if the
+// signature wasn't calculated for a set of platforms.
+func (s Signature) AvailableOn(item string) []string {
+	if platforms, ok := s.Availability[item]; ok {
+		return platforms
+	}
+
+	return s.Platforms
+}
+
+// Position is a location in a Go source file.
+type Position struct {
+	// File is the path of the file, relative to the directory being analysed.
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column,omitempty"`
 }
 
 // GetFromDirectory gets the signature of a directory of Go files, including subdirectories.
 func GetFromDirectory(gopath string, dir string) (PackageSignatures, error) {
+	return GetFromDirectoryWithOptions(gopath, dir, Options{})
+}
+
+// GetFromDirectoryWithOptions gets the signature of a directory of Go files, including
+// subdirectories, using the options to decide which files are included. If the options
+// include platforms, the signature is calculated for each platform and merged.
+func GetFromDirectoryWithOptions(gopath string, dir string, opts Options) (PackageSignatures, error) {
+	if len(opts.Platforms) == 0 {
+		ctx := build.Default
+		ctx.GOPATH = gopath
+		return getFromContext(ctx, gopath, dir, opts)
+	}
+
+	signatures := map[string]PackageSignatures{}
+
+	for _, p := range opts.Platforms {
+		ps, err := getFromContext(p.context(gopath), gopath, dir, opts)
+
+		if err == ErrNoGoFiles {
+			// None of the files are built on this platform.
+			continue
+		}
+
+		if le, ok := err.(LoadError); ok {
+			le.Err = fmt.Errorf("%v: %v", p, le.Err)
+			return PackageSignatures{}, le
+		}
+
+		if err != nil {
+			return PackageSignatures{}, err
+		}
+
+		signatures[p.String()] = ps
+	}
+
+	if len(signatures) == 0 {
+		return PackageSignatures{}, ErrNoGoFiles
+	}
+
+	return mergePlatforms(opts.Platforms, signatures), nil
+}
+
+func getFromContext(ctx build.Context, gopath string, dir string, opts Options) (PackageSignatures, error) {
 	// Iterate subdirectories too.
-	directories, err := walkDirectories(dir)
+	directories, err := walkDirectories(dir, opts)
 
 	if err != nil {
 		return PackageSignatures{}, err
 	}
 
 	// Import the directories
-	ctx := build.Default
-	ctx.GOPATH = gopath
 	conf := loader.Config{
 		Build: &ctx,
+		// Comments are used to find deprecated items.
+		ParserMode: parser.ParseComments,
+	}
+
+	// Collect all of the errors, rather than writing them to stderr.
+	var typeErrors []TypeError
+	conf.TypeChecker.Error = func(err error) {
+		typeErrors = append(typeErrors, newTypeErrors(dir, err)...)
 	}
 
 	for _, d := range directories {
-		filenames, err := getFiles(d)
+		filenames, err := getFiles(dir, d, opts)
 
 		if err != nil {
 			return PackageSignatures{}, err
 		}
 
-		conf.CreatePkgs = append(conf.CreatePkgs, loader.PkgSpec{Path: d, Filenames: filenames})
+		// Only include the files which are built for the platform and build tags of the context.
+		filenames = matchFiles(ctx, filenames)
+
+		if len(filenames) == 0 {
+			continue
+		}
+
+		conf.CreatePkgs = append(conf.CreatePkgs, loader.PkgSpec{Path: importPath(gopath, d), Filenames: filenames})
+	}
+
+	if len(conf.CreatePkgs) == 0 {
+		return PackageSignatures{}, ErrNoGoFiles
 	}
 
 	prog, err := conf.Load()
 
 	if err != nil {
-		return PackageSignatures{}, err
+		return PackageSignatures{}, LoadError{Err: err, TypeErrors: typeErrors}
 	}
 
-	return GetFromProgram(prog, dir), err
+	return getFromProgram(prog, importPath(gopath, dir), dir, opts, &ctx), err
 }
 
-func walkDirectories(dir string) ([]string, error) {
+// importPath returns the import path of a directory within the gopath, e.g. "github.com/a-h/ver".
+// If the directory isn't within the gopath, the directory itself is used as the path, so that
+// the output doesn't depend on where the gopath is located on disk.
+func importPath(gopath string, dir string) string {
+	for _, p := range filepath.SplitList(gopath) {
+		rel, err := filepath.Rel(filepath.Join(p, "src"), dir)
+
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+
+		return filepath.ToSlash(rel)
+	}
+
+	return dir
+}
+
+func walkDirectories(dir string, opts Options) ([]string, error) {
 	rv := []string{}
 
 	err := filepath.Walk(dir, func(walkedPath string, f os.FileInfo, err error) error {
@@ -74,14 +204,14 @@ func walkDirectories(dir string) ([]string, error) {
 			return err
 		}
 
-		if f.IsDir() && f.Name()[0] == '.' {
-			return filepath.SkipDir
-		}
-
 		if !f.IsDir() {
 			return nil
 		}
 
+		if !opts.includeDir(dir, walkedPath) {
+			return filepath.SkipDir
+		}
+
 		rv = append(rv, walkedPath)
 
 		return nil
@@ -90,7 +220,7 @@ func walkDirectories(dir string) ([]string, error) {
 	return rv, err
 }
 
-func getFiles(dir string) ([]string, error) {
+func getFiles(root string, dir string, opts Options) ([]string, error) {
 	files := []string{}
 
 	fi, err := ioutil.ReadDir(dir)
@@ -100,8 +230,10 @@ func getFiles(dir string) ([]string, error) {
 	}
 
 	for _, f := range fi {
-		if !f.IsDir() && filepath.Ext(f.Name()) == ".go" {
-			files = append(files, path.Join(dir, f.Name()))
+		filename := path.Join(dir, f.Name())
+
+		if !f.IsDir() && opts.includeFile(root, filename) {
+			files = append(files, filename)
 		}
 	}
 
@@ -109,105 +241,192 @@ func getFiles(dir string) ([]string, error) {
 }
 
 // GetFromProgram gets a set of signatures for a program loaded with the loader.Config.
-// Only packages with a matching prefix will be extracted.
+// Only packages with a matching prefix will be extracted. Internal and main packages
+// aren't part of the public API, so they're not extracted.
 func GetFromProgram(prog *loader.Program, prefix string) PackageSignatures {
+	return getFromProgram(prog, prefix, "", Options{}, nil)
+}
+
+// getFromProgram gets the signatures of the packages in the program. The positions of items
+// are relative to dir, and aren't recorded if dir isn't set. If the build context is set, it's
+// used to load the well-known interfaces which the program doesn't import, and the memory
+// layout of structs on its architecture is included when the Layout option is set.
+func getFromProgram(prog *loader.Program, prefix string, dir string, opts Options, ctx *build.Context) PackageSignatures {
 	rv := PackageSignatures{}
 
-	for pkg := range prog.AllPackages {
-		path := pkg.Path()
+	arch := ""
+
+	if opts.Layout && ctx != nil {
+		arch = ctx.GOARCH
+	}
+
+	// Created packages take priority over imported packages which have the same path.
+	packages := []*loader.PackageInfo{}
+
+	packages = append(packages, prog.Created...)
+
+	for _, info := range prog.AllPackages {
+		packages = append(packages, info)
+	}
+
+	included := map[string]*loader.PackageInfo{}
+	interfaces := lookupInterfaces(prog, opts.interfaces(), ctx)
+
+	for _, info := range packages {
+		path := info.Pkg.Path()
 
 		// Filter by prefix.
-		if strings.Index(path, prefix) != 0 {
+		if !hasPathPrefix(path, prefix) {
+			continue
+		}
+
+		if !opts.includePackage(prefix, path, info.Pkg.Name()) {
 			continue
 		}
 
-		rv[path] = GetFromScope(pkg.Scope())
+		if _, ok := included[path]; ok {
+			continue
+		}
+
+		included[path] = info
+		interfaces = append(interfaces, packageInterfaces(info.Pkg)...)
+	}
+
+	for path, info := range included {
+		positions := map[string]token.Pos{}
+		sig := getFromScope(info.Pkg.Scope(), positions, interfaces)
+		sig.Positions = newPositions(prog.Fset, dir, positions)
+
+		if arch != "" {
+			addLayouts(&sig, info.Pkg.Scope(), arch)
+		}
+
+		addImplements(&sig, info.Pkg.Scope(), interfaces)
+
+		if opts.Initializers {
+			addInitializers(&sig, info.Files)
+		}
+
+		addDeprecated(&sig, info.Files)
+		addIotaBlocks(&sig, info.Files)
+
+		rv[path] = sig
 	}
 
 	return rv
 }
 
-// GetFromScope gets a Signature for a given Scope.
+func hasPathPrefix(path string, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/")
+}
+
+// GetFromScope gets a Signature for a given Scope. Unexported types which are reachable from
+// exported declarations, e.g. the result of an exported function, are included, since their
+// exported methods and fields can still be used.
 func GetFromScope(s *types.Scope) Signature {
+	return getFromScope(s, nil, nil)
+}
+
+// getFromScope gets a Signature for a given Scope. If positions isn't nil, the position each
+// item is declared at is added to it. The interfaces which the type of each parameter of a function
+// implements are recorded.
+func getFromScope(s *types.Scope, positions map[string]token.Pos, interfaces []namedInterface) Signature {
 	rv := NewSignature()
 
 	for _, sn := range s.Names() {
 		lookup := s.Lookup(sn)
-		lookupType := lookup.Type()
 
 		if !lookup.Exported() {
 			continue
 		}
 
-		switch lookup.(type) {
-		case *types.Func:
-			rv.Functions = append(rv.Functions, lookup.String())
-			break
-		case *types.Var:
-			rv.Fields = append(rv.Fields, lookup.String())
-			break
-		case *types.Const:
-			value := lookup.(*types.Const).Val().String()
-			rv.Constants = append(rv.Constants, lookup.String()+" = "+value)
-			break
-		}
+		addObject(&rv, sn, lookup, positions, interfaces)
+	}
 
-		switch lookupType.Underlying().(type) {
-		case *types.Struct:
-			rv.Structs = append(rv.Structs, renderStruct(sn, lookupType.Underlying().(*types.Struct)))
-			break
-		case *types.Interface:
-			rv.Interfaces = append(rv.Interfaces, lookupType.String())
-			break
-		}
-
-		// Extract methods from structs, interfaces and pointers to structs.
-		for _, msetType := range []types.Type{lookupType, types.NewPointer(lookupType)} {
-			mset := types.NewMethodSet(msetType)
-			for i := 0; i < mset.Len(); i++ {
-				method := mset.At(i)
-				if method.Obj().Exported() {
-					rv.Functions = append(rv.Functions, method.String())
-				}
-			}
-		}
+	for _, tn := range reachable(s) {
+		addObject(&rv, tn.Name(), tn, positions, interfaces)
 	}
 
-	return rv
+	return rv.sorted()
 }
 
-func renderStruct(name string, s *types.Struct) string {
-	msg := bytes.NewBufferString("struct")
+// add appends the item to the list. If positions isn't nil, the position the item is
+// declared at is added to it.
+func add(list *[]string, item string, pos token.Pos, positions map[string]token.Pos) {
+	*list = append(*list, item)
 
-	if name != "" {
-		msg.WriteString(" " + name)
+	if positions != nil {
+		positions[item] = pos
 	}
+}
 
-	msg.WriteString(" {")
+// addObject adds an object in the scope to the signature.
+func addObject(rv *Signature, sn string, lookup types.Object, positions map[string]token.Pos, interfaces []namedInterface) {
+	lookupType := lookup.Type()
 
-	fieldCount := s.NumFields()
+	if tn, isTypeName := lookup.(*types.TypeName); isTypeName {
+		if tn.IsAlias() {
+			// The methods and fields of an alias belong to the type it refers to.
+			add(&rv.Types, "type "+tn.Pkg().Path()+"."+tn.Name()+" = "+types.TypeString(types.Unalias(lookupType), nil), lookup.Pos(), positions)
+			return
+		}
 
-	for fi := 0; fi < fieldCount; fi++ {
-		field := s.Field(fi)
+		// Structs and interfaces are recorded separately.
+		switch lookupType.Underlying().(type) {
+		case *types.Struct, *types.Interface:
+		default:
+			add(&rv.Types, "type "+lookupType.String()+" "+types.TypeString(lookupType.Underlying(), nil), lookup.Pos(), positions)
+		}
 
-		if field.Exported() {
-			s, isStruct := field.Type().Underlying().(*types.Struct)
+		if !types.IsInterface(lookupType) {
+			rv.setMethodSet(sn, newMethodSet(lookupType))
+		}
+	}
 
-			if isStruct {
-				msg.WriteString(fmt.Sprintf(" %s %s", field.Name(), renderStruct("", s)))
-			} else {
-				msg.WriteString(" " + field.String())
-			}
+	switch lookup.(type) {
+	case *types.Func:
+		add(&rv.Functions, lookup.String(), lookup.Pos(), positions)
+		rv.setFunc(sn, newFunc(lookupType.(*types.Signature), interfaces))
+		break
+	case *types.Var:
+		add(&rv.Fields, lookup.String(), lookup.Pos(), positions)
+		break
+	case *types.Const:
+		value := lookup.(*types.Const).Val().String()
+		add(&rv.Constants, lookup.String()+" = "+value, lookup.Pos(), positions)
+		break
+	}
 
-			if fi < fieldCount-2 {
-				msg.WriteString(",")
-			} else {
-				msg.WriteString(" ")
-			}
+	switch lookupType.Underlying().(type) {
+	case *types.Struct:
+		add(&rv.Structs, renderStruct(sn, lookupType.Underlying().(*types.Struct)), lookup.Pos(), positions)
+		if rv.StructDetails == nil {
+			rv.StructDetails = map[string]Struct{}
 		}
+		details := newStruct(lookupType.Underlying().(*types.Struct))
+		details.Promoted = promoted(lookupType)
+		rv.StructDetails[sn] = details
+		break
+	case *types.Interface:
+		add(&rv.Interfaces, lookupType.String(), lookup.Pos(), positions)
+		break
 	}
 
-	msg.WriteString("}")
-
-	return msg.String()
+	// Extract methods from structs, interfaces and pointers to structs.
+	for _, msetType := range []types.Type{lookupType, types.NewPointer(lookupType)} {
+		mset := types.NewMethodSet(msetType)
+		for i := 0; i < mset.Len(); i++ {
+			method := mset.At(i)
+			if method.Obj().Exported() {
+				// Methods promoted from another package are positioned at the type which embeds them,
+				// since their declaration isn't part of the code being analysed.
+				pos := method.Obj().Pos()
+				if method.Obj().Pkg() != lookup.Pkg() {
+					pos = lookup.Pos()
+				}
+				add(&rv.Functions, method.String(), pos, positions)
+				rv.setFunc(methodName(msetType, method.Obj().Name()), newFunc(method.Obj().Type().(*types.Signature), interfaces))
+			}
+		}
+	}
 }
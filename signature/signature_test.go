@@ -7,6 +7,7 @@ import (
 	"go/parser"
 	"go/token"
 	"go/types"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -44,8 +45,8 @@ func Test_GetInformationFromProgram(t *testing.T) {
 			expected: Signature{
 				Structs: []string{"struct Test {}"},
 				Functions: []string{
-					"method (github.com/a-h/nonexistent.Test) GetStringReceiver() string",
 					"method (*github.com/a-h/nonexistent.Test) GetStringReceiver() string",
+					"method (github.com/a-h/nonexistent.Test) GetStringReceiver() string",
 				},
 			},
 		},
@@ -97,9 +98,33 @@ func Test_GetInformationFromProgram(t *testing.T) {
 			},
 		},
 		{
-			name:     "Types are extracted",
-			code:     []string{"package nonexistent", "type Test int"},
-			expected: Signature{},
+			name: "Types are extracted",
+			code: []string{"package nonexistent", "type Test int"},
+			expected: Signature{
+				Types: []string{"type github.com/a-h/nonexistent.Test int"},
+			},
+		},
+		{
+			name: "Types are extracted with their methods",
+			code: []string{"package nonexistent", "type Names []string", "func (n Names) Len() int { return len(n) }"},
+			expected: Signature{
+				Types: []string{"type github.com/a-h/nonexistent.Names []string"},
+				Functions: []string{
+					"method (*github.com/a-h/nonexistent.Names) Len() int",
+					"method (github.com/a-h/nonexistent.Names) Len() int",
+				},
+			},
+		},
+		{
+			name: "Aliases are extracted with the type they refer to",
+			code: []string{"package nonexistent", "type Test struct { Name string }", "type Alias = Test", "type Number = int"},
+			expected: Signature{
+				Structs: []string{"struct Test { field Name string }"},
+				Types: []string{
+					"type github.com/a-h/nonexistent.Alias = github.com/a-h/nonexistent.Test",
+					"type github.com/a-h/nonexistent.Number = int",
+				},
+			},
 		},
 		{
 			name: "Anonymous nested structs are extracted without public fields",
@@ -108,6 +133,26 @@ func Test_GetInformationFromProgram(t *testing.T) {
 				Structs: []string{"struct Test { A struct { field B string } }"},
 			},
 		},
+		{
+			name: "Unexported types which are reachable from exported declarations are extracted",
+			code: []string{"package nonexistent", "type client struct { Name string; secret string; Options options }", "type options map[string]string",
+				"func (c *client) Do() error { return nil }", "func (c *client) do() {}", "func New() *client { return nil }", "type hidden int"},
+			expected: Signature{
+				Functions: []string{
+					"func github.com/a-h/nonexistent.New() *github.com/a-h/nonexistent.client",
+					"method (*github.com/a-h/nonexistent.client) Do() error",
+				},
+				Structs: []string{"struct client { field Name string, field Options github.com/a-h/nonexistent.options }"},
+				Types:   []string{"type github.com/a-h/nonexistent.options map[string]string"},
+			},
+		},
+		{
+			name: "Structs include their fields in order, and their tags",
+			code: []string{"package nonexistent", "type Test struct { C string `json:\"c\"`; B int; A bool `json:\"a,omitempty\"` }"},
+			expected: Signature{
+				Structs: []string{"struct Test { field C string `json:\"c\"`, field B int, field A bool `json:\"a,omitempty\"` }"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		pkg, err := parseGoIntoPackage(basePackage, strings.Join(tt.code, "\n"))
@@ -133,6 +178,145 @@ func Test_GetInformationFromProgram(t *testing.T) {
 
 		compareLengths(tt.name, "Interfaces", tt.expected.Interfaces, actual.Interfaces, t)
 		compareElements(tt.name, "Interfaces", tt.expected.Interfaces, actual.Interfaces, t)
+
+		compareLengths(tt.name, "Types", tt.expected.Types, actual.Types, t)
+		compareElements(tt.name, "Types", tt.expected.Types, actual.Types, t)
+	}
+}
+
+func TestThatStructDetailsAreExtracted(t *testing.T) {
+	pkg, err := parseGoIntoPackage("github.com/a-h/nonexistent", "package nonexistent\ntype Test struct { B int `json:\"b\"`; a string; A []string }")
+
+	if err != nil {
+		t.Fatalf("failed to parse Go with error %v", err)
+	}
+
+	actual := GetFromScope(pkg.Scope()).StructDetails["Test"]
+
+	expected := Struct{
+		Fields: []Field{
+			{Name: "B", Type: "int", Tag: `json:"b"`},
+			{Name: "A", Type: "[]string"},
+		},
+		Comparable: false,
+		Unexported: true,
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
+
+func TestThatMethodSetsOfValuesAndPointersAreExtracted(t *testing.T) {
+	code := strings.Join([]string{
+		"package nonexistent",
+		"type Test struct {}",
+		"func (t Test) A() {}",
+		"func (t *Test) B() {}",
+		"func (t *Test) c() {}",
+		"type Mode int",
+		"type Closer interface { Close() }",
+	}, "\n")
+
+	pkg, err := parseGoIntoPackage("github.com/a-h/nonexistent", code)
+
+	if err != nil {
+		t.Fatalf("failed to parse Go with error %v", err)
+	}
+
+	expected := map[string]MethodSet{
+		"Test": MethodSet{Value: []string{"A"}, Pointer: []string{"A", "B"}},
+		"Mode": MethodSet{},
+	}
+
+	if actual := GetFromScope(pkg.Scope()).MethodSets; !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
+
+func TestThatPromotedMethodsAndFieldsAreExtracted(t *testing.T) {
+	code := strings.Join([]string{
+		"package nonexistent",
+		"type Base struct { ID string; Name string }",
+		"func (b Base) Describe() string { return b.Name }",
+		"func (b *Base) Rename(name string) { b.Name = name }",
+		"type Named struct { Base; Name string }",
+		"type Test struct { *Named }",
+	}, "\n")
+
+	pkg, err := parseGoIntoPackage("github.com/a-h/nonexistent", code)
+
+	if err != nil {
+		t.Fatalf("failed to parse Go with error %v", err)
+	}
+
+	expected := []Promoted{
+		{Name: "Describe", Kind: "method", Type: "func() string", Via: "Named.Base", Origin: "github.com/a-h/nonexistent"},
+		{Name: "Rename", Kind: "method", Type: "func(name string)", Via: "Named.Base", Origin: "github.com/a-h/nonexistent"},
+		{Name: "Base", Kind: "field", Type: "github.com/a-h/nonexistent.Base", Via: "Named", Origin: "github.com/a-h/nonexistent"},
+		{Name: "Name", Kind: "field", Type: "string", Via: "Named", Origin: "github.com/a-h/nonexistent"},
+		{Name: "ID", Kind: "field", Type: "string", Via: "Named.Base", Origin: "github.com/a-h/nonexistent"},
+	}
+
+	if actual := GetFromScope(pkg.Scope()).StructDetails["Test"].Promoted; !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected:\n%v\nbut got:\n%v", expected, actual)
+	}
+}
+
+func TestThatFunctionDetailsAreExtracted(t *testing.T) {
+	code := strings.Join([]string{
+		"package nonexistent",
+		"type Test struct {}",
+		"func (t *Test) Write(p []byte) (n int, err error) { return 0, nil }",
+		"func Join(sep string, values ...interface{ String() string }) string { return sep }",
+	}, "\n")
+
+	pkg, err := parseGoIntoPackage("github.com/a-h/nonexistent", code)
+
+	if err != nil {
+		t.Fatalf("failed to parse Go with error %v", err)
+	}
+
+	expected := map[string]Func{
+		"(*Test).Write": Func{
+			Params:  []Param{{Name: "p", Type: "[]byte"}},
+			Results: []Param{{Name: "n", Type: "int"}, {Name: "err", Type: "error", Interface: true}},
+		},
+		"Join": Func{
+			Params:   []Param{{Name: "sep", Type: "string"}, {Name: "values", Type: "[]interface{String() string}"}},
+			Results:  []Param{{Type: "string"}},
+			Variadic: true,
+		},
+	}
+
+	if actual := GetFromScope(pkg.Scope()).Funcs; !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
+
+func TestThatFunctionDetailsOfMethodsOnGenericTypesAreKeyedWithoutTypeArguments(t *testing.T) {
+	code := strings.Join([]string{
+		"package nonexistent",
+		"type List[T any] struct { items []T }",
+		"func (l *List[T]) Push(v T) { l.items = append(l.items, v) }",
+	}, "\n")
+
+	pkg, err := parseGoIntoPackage("github.com/a-h/nonexistent", code)
+
+	if err != nil {
+		t.Fatalf("failed to parse Go with error %v", err)
+	}
+
+	sig := GetFromScope(pkg.Scope())
+
+	if !contains(sig.Functions, "method (*github.com/a-h/nonexistent.List[T any]) Push(v T)") {
+		t.Errorf("expected the method to be extracted, but got %v", sig.Functions)
+	}
+
+	expected := Func{Params: []Param{{Name: "v", Type: "T", Interface: true}}}
+
+	if actual, ok := sig.Funcs["(*List).Push"]; !ok || !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected the details of (*List).Push, but got %v", sig.Funcs)
 	}
 }
 
@@ -0,0 +1,214 @@
+package signature
+
+import (
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// Struct describes the exported fields of a struct, in the order they're declared.
+type Struct struct {
+	Fields []Field `json:"fields"`
+	// Comparable is true when values of the struct can be compared with == and used as map keys.
+	Comparable bool `json:"comparable"`
+	// Unexported is true when the struct has unexported fields, so it can't be created with an
+	// unkeyed composite literal outside of its package.
+	Unexported bool `json:"unexported,omitempty"`
+	// Layouts are the memory layout of the struct, keyed by architecture, e.g. "amd64". They're
+	// only recorded when the Layout option is set.
+	Layouts map[string]Layout `json:"layouts,omitempty"`
+	// Promoted are the methods and fields promoted from embedded types.
+	Promoted []Promoted `json:"promoted,omitempty"`
+}
+
+// Field is an exported field of a struct.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Tag  string `json:"tag,omitempty"`
+}
+
+// Field returns the field with the given name, and its index, or -1 if the struct doesn't have the field.
+func (s Struct) Field(name string) (Field, int) {
+	for i, f := range s.Fields {
+		if f.Name == name {
+			return f, i
+		}
+	}
+
+	return Field{}, -1
+}
+
+func newStruct(s *types.Struct) Struct {
+	rv := Struct{
+		Comparable: types.Comparable(s),
+	}
+
+	for fi := 0; fi < s.NumFields(); fi++ {
+		field := s.Field(fi)
+
+		if !field.Exported() {
+			rv.Unexported = true
+			continue
+		}
+
+		f := Field{
+			Name: field.Name(),
+			Type: types.TypeString(field.Type(), nil),
+			Tag:  s.Tag(fi),
+		}
+
+		if st, isStruct := field.Type().(*types.Struct); isStruct {
+			f.Type = renderStruct("", st)
+		}
+
+		rv.Fields = append(rv.Fields, f)
+	}
+
+	return rv
+}
+
+func renderStruct(name string, s *types.Struct) string {
+	fields := []string{}
+
+	for fi := 0; fi < s.NumFields(); fi++ {
+		field := s.Field(fi)
+
+		if !field.Exported() {
+			continue
+		}
+
+		var f string
+
+		if st, isStruct := field.Type().Underlying().(*types.Struct); isStruct {
+			f = field.Name() + " " + renderStruct("", st)
+		} else {
+			f = field.String()
+		}
+
+		fields = append(fields, f+renderTag(s.Tag(fi)))
+	}
+
+	return joinStruct(name, fields)
+}
+
+// renderTag renders a struct tag as it would be written in the source, e.g. " `json:\"name\"`".
+func renderTag(tag string) string {
+	if tag == "" {
+		return ""
+	}
+
+	return " `" + tag + "`"
+}
+
+// joinStruct renders a struct from its rendered fields, e.g. "struct Test { field A string, field B int }".
+func joinStruct(name string, fields []string) string {
+	rv := "struct"
+
+	if name != "" {
+		rv += " " + name
+	}
+
+	if len(fields) == 0 {
+		return rv + " {}"
+	}
+
+	return rv + " { " + strings.Join(fields, ", ") + " }"
+}
+
+// mergeDetails adds the details of items from other which aren't already in the signature.
+func (s *Signature) mergeDetails(other Signature) {
+	for name, st := range other.StructDetails {
+		if existing, ok := s.StructDetails[name]; ok {
+			// Each platform adds the layout of the struct on its architecture.
+			for arch, l := range st.Layouts {
+				if existing.Layouts == nil {
+					existing.Layouts = map[string]Layout{}
+				}
+				existing.Layouts[arch] = l
+			}
+			s.StructDetails[name] = existing
+			continue
+		}
+
+		if s.StructDetails == nil {
+			s.StructDetails = map[string]Struct{}
+		}
+
+		s.StructDetails[name] = st
+	}
+
+	for name, implemented := range other.Implements {
+		if s.Implements == nil {
+			s.Implements = map[string][]string{}
+		}
+
+		for _, i := range implemented {
+			if !contains(s.Implements[name], i) {
+				s.Implements[name] = append(s.Implements[name], i)
+			}
+		}
+
+		sort.Strings(s.Implements[name])
+	}
+
+	for name, value := range other.Initializers {
+		if _, ok := s.Initializers[name]; ok {
+			continue
+		}
+
+		if s.Initializers == nil {
+			s.Initializers = map[string]string{}
+		}
+
+		s.Initializers[name] = value
+	}
+
+	for name, block := range other.IotaBlocks {
+		if _, ok := s.IotaBlocks[name]; ok {
+			continue
+		}
+
+		if s.IotaBlocks == nil {
+			s.IotaBlocks = map[string]string{}
+		}
+
+		s.IotaBlocks[name] = block
+	}
+
+	for name, message := range other.Deprecated {
+		if _, ok := s.Deprecated[name]; ok {
+			continue
+		}
+
+		if s.Deprecated == nil {
+			s.Deprecated = map[string]string{}
+		}
+
+		s.Deprecated[name] = message
+	}
+
+	for item, p := range other.Positions {
+		if _, ok := s.Positions[item]; ok {
+			continue
+		}
+
+		if s.Positions == nil {
+			s.Positions = map[string]Position{}
+		}
+
+		s.Positions[item] = p
+	}
+
+	for name, f := range other.Funcs {
+		if _, ok := s.Funcs[name]; !ok {
+			s.setFunc(name, f)
+		}
+	}
+
+	for name, ms := range other.MethodSets {
+		if _, ok := s.MethodSets[name]; !ok {
+			s.setMethodSet(name, ms)
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package main
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // Version represents a major, minor and build version.
 type Version struct {
@@ -26,3 +31,50 @@ func (v Version) Add(d Version) Version {
 		Build: v.Build + d.Build,
 	}
 }
+
+// UnmarshalJSON reads a version in the format written by MarshalJSON.
+func (v *Version) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseVersion(s)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// ParseVersion parses a version in the format "major.minor.build", e.g. "1.2.3".
+func ParseVersion(s string) (Version, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version '%s', expected the format major.minor.build", s)
+	}
+	values := make([]int, len(parts))
+	for i, p := range parts {
+		value, err := strconv.Atoi(p)
+		if err != nil || value < 0 {
+			return Version{}, fmt.Errorf("invalid version '%s', '%s' is not a positive number", s, p)
+		}
+		values[i] = value
+	}
+	return Version{Major: values[0], Minor: values[1], Build: values[2]}, nil
+}
+
+// Max returns the largest value of each part of the two versions.
+func (v Version) Max(o Version) Version {
+	return Version{
+		Major: max(v.Major, o.Major),
+		Minor: max(v.Minor, o.Minor),
+		Build: max(v.Build, o.Build),
+	}
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
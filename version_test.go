@@ -81,3 +81,61 @@ func TestVersionJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestVersionsCanBeParsed(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Version
+		err      bool
+	}{
+		{
+			input:    "1.2.3",
+			expected: Version{1, 2, 3},
+		},
+		{
+			input:    "v0.10.0",
+			expected: Version{0, 10, 0},
+		},
+		{
+			input: "1.2",
+			err:   true,
+		},
+		{
+			input: "1.a.3",
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := ParseVersion(test.input)
+
+		if test.err != (err != nil) {
+			t.Errorf("for %v; expected error %v, but got %v", test.input, test.err, err)
+		}
+
+		if actual != test.expected {
+			t.Errorf("for %v; expected %v, but got %v", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestVersionJSONCanBeRead(t *testing.T) {
+	var actual Version
+	if err := json.Unmarshal([]byte("\"1.2.3\""), &actual); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v\n", err)
+	}
+
+	expected := Version{1, 2, 3}
+	if actual != expected {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
+
+func TestTheMaximumOfVersionsCanBeCalculated(t *testing.T) {
+	actual := Version{1, 0, 3}.Max(Version{0, 2, 1})
+
+	expected := Version{1, 2, 3}
+	if actual != expected {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}